@@ -0,0 +1,57 @@
+package namer
+
+import (
+	"strings"
+)
+
+// WithAbbreviations abbreviates words of every segment except the base name
+// using the dictionary. E.g.: {"service-account": "sa", "production": "prd"}.
+// Whole segment values are matched first, then words between hyphens,
+// underscores or other punctuation. Matches ignore case and are applied before
+// WithReplace.
+func WithAbbreviations(abbreviations map[string]string) Option {
+	return func(n *Namer) {
+		n.abbreviations = abbreviations
+	}
+}
+
+// applyAbbreviations abbreviates the segment values with the dictionary.
+func (e Namer) applyAbbreviations(segments []Segment) []Segment {
+	abbreviated := make([]Segment, len(segments))
+	for i, segment := range segments {
+		segment.Value = e.abbreviate(segment.Value)
+		abbreviated[i] = segment
+	}
+
+	return abbreviated
+}
+
+// abbreviate abbreviates the value, or each of its words.
+func (e Namer) abbreviate(value string) string {
+	if abbreviation, ok := e.abbreviations[strings.ToLower(value)]; ok {
+		return abbreviation
+	}
+
+	var abbreviated, word strings.Builder
+	flush := func() {
+		if abbreviation, ok := e.abbreviations[strings.ToLower(word.String())]; ok && word.Len() > 0 {
+			abbreviated.WriteString(abbreviation)
+		} else {
+			abbreviated.WriteString(word.String())
+		}
+		word.Reset()
+	}
+
+	for _, r := range value {
+		if isLowerLetter(r) || isDigit(r) || (r >= 'A' && r <= 'Z') {
+			word.WriteRune(r)
+
+			continue
+		}
+		flush()
+		abbreviated.WriteRune(r)
+	}
+	flush()
+
+	return abbreviated.String()
+}
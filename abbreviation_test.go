@@ -0,0 +1,83 @@
+package namer_test
+
+import (
+	"testing"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+)
+
+func TestNewResourceName_WithAbbreviations(t *testing.T) {
+	t.Parallel()
+
+	abbreviations := map[string]string{
+		"service-account": "sa",
+		"processor":       "proc",
+		"production":      "prd",
+	}
+
+	tests := []struct {
+		name         string
+		baseName     string
+		opts         []namer.Option
+		serviceName  string
+		resourceType string
+		maxLength    int
+		expected     string
+	}{
+		{
+			name:         "whole value abbreviated",
+			baseName:     "my-prod-stack",
+			serviceName:  "orders",
+			resourceType: "service-account",
+			maxLength:    63,
+			expected:     "my-prod-stack-orders-sa",
+		},
+		{
+			name:         "words abbreviated",
+			baseName:     "my-prod-stack",
+			serviceName:  "backend-processor-production",
+			resourceType: "topic",
+			maxLength:    63,
+			expected:     "my-prod-stack-backend-proc-prd-topic",
+		},
+		{
+			name:         "base name left as is",
+			baseName:     "production",
+			serviceName:  "orders",
+			resourceType: "bucket",
+			maxLength:    63,
+			expected:     "production-orders-bucket",
+		},
+		{
+			name:         "case ignored before replacements",
+			baseName:     "my-prod-stack",
+			opts:         []namer.Option{namer.WithReplace()},
+			serviceName:  "Backend_Processor",
+			resourceType: "Service-Account",
+			maxLength:    63,
+			expected:     "my-prod-stack-backend-proc-sa",
+		},
+		{
+			name:         "abbreviated before truncating",
+			baseName:     "my-prod-stack",
+			serviceName:  "backend-processor",
+			resourceType: "service-account",
+			maxLength:    20,
+			expected:     "my-p-backend-proc-sa",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := append([]namer.Option{namer.WithAbbreviations(abbreviations)}, testCase.opts...)
+			n := namer.New(testCase.baseName, opts...)
+			result := n.NewResourceName(testCase.serviceName, testCase.resourceType, testCase.maxLength)
+
+			if result != testCase.expected {
+				t.Errorf("NewResourceName() = %v, want %v", result, testCase.expected)
+			}
+		})
+	}
+}
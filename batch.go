@@ -0,0 +1,77 @@
+package namer
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ResourceSpec describes a resource name to generate in a batch.
+type ResourceSpec struct {
+	// Name is the resource name. E.g.: orders.
+	Name string
+	// Type is the resource type or group. E.g.: bucket.
+	Type string
+	// MaxLength is the max length of the generated name.
+	MaxLength int
+}
+
+// String describes the spec in error messages.
+func (s ResourceSpec) String() string {
+	return fmt.Sprintf("%s/%s (max %d)", s.Name, s.Type, s.MaxLength)
+}
+
+// NewResourceNameBatch generates the names of every spec at once, in the same
+// order. Unlike NewResourceName it doesn't panic: every invalid spec and every
+// pair of specs generating the same name is returned as one error. Results and
+// errors don't depend on the order of the specs.
+func (e Namer) NewResourceNameBatch(specs []ResourceSpec) ([]string, error) {
+	order := make([]int, len(specs))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return compareSpecs(specs[a], specs[b])
+	})
+
+	var errs []error
+	names := make([]string, len(specs))
+	sources := make(map[string]ResourceSpec, len(specs))
+	for _, i := range order {
+		spec := specs[i]
+		name, _, err := e.generate(spec.MaxLength, []Segment{
+			{Name: SegmentName, Value: spec.Name},
+			{Name: SegmentType, Value: spec.Type},
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", spec, err))
+
+			continue
+		}
+
+		if source, ok := sources[name]; ok {
+			errs = append(errs, e.notifyCollision(name, source.String(), spec.String()))
+
+			continue
+		}
+		sources[name] = spec
+		names[i] = name
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	e.notifyGenerated(names...)
+
+	return names, nil
+}
+
+// compareSpecs orders specs by name, type and max length.
+func compareSpecs(a, b ResourceSpec) int {
+	return cmp.Or(
+		cmp.Compare(a.Name, b.Name),
+		cmp.Compare(a.Type, b.Type),
+		cmp.Compare(a.MaxLength, b.MaxLength),
+	)
+}
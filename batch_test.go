@@ -0,0 +1,113 @@
+package namer_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+)
+
+func TestNewResourceNameBatch(t *testing.T) {
+	t.Parallel()
+
+	n := namer.New("my-prod-stack")
+	specs := []namer.ResourceSpec{
+		{Name: "orders", Type: "bucket", MaxLength: 63},
+		{Name: "backend-processor", Type: "service-account", MaxLength: 30},
+		{Name: "orders", Type: "topic", MaxLength: 63},
+	}
+
+	names, err := n.NewResourceNameBatch(specs)
+	if err != nil {
+		t.Fatalf("NewResourceNameBatch() error = %v", err)
+	}
+
+	for i, spec := range specs {
+		expected := n.NewResourceName(spec.Name, spec.Type, spec.MaxLength)
+		if names[i] != expected {
+			t.Errorf("NewResourceNameBatch()[%d] = %v, want %v", i, names[i], expected)
+		}
+	}
+}
+
+func TestNewResourceNameBatch_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		specs          []namer.ResourceSpec
+		expectedErrors []string
+	}{
+		{
+			name: "every invalid spec is reported",
+			specs: []namer.ResourceSpec{
+				{Name: "orders", Type: "bucket-", MaxLength: 63},
+				{Name: "orders", Type: "topic", MaxLength: 63},
+				{Name: "Orders", Type: "queue", MaxLength: 63},
+			},
+			expectedErrors: []string{
+				"orders/bucket- (max 63): name must end with a lowercase letter or digit",
+				`Orders/queue (max 63): name must only contain lowercase letters, digits and "-", found "O"`,
+			},
+		},
+		{
+			name: "names colliding after truncation",
+			specs: []namer.ResourceSpec{
+				{Name: "worker-10", Type: "pod", MaxLength: 12},
+				{Name: "worker-11", Type: "pod", MaxLength: 12},
+			},
+			expectedErrors: []string{
+				`worker-10/pod (max 12) and worker-11/pod (max 12) both generate name "my-pr-wor-p"`,
+			},
+		},
+		{
+			name: "duplicate specs",
+			specs: []namer.ResourceSpec{
+				{Name: "orders", Type: "bucket", MaxLength: 63},
+				{Name: "orders", Type: "bucket", MaxLength: 63},
+			},
+			expectedErrors: []string{
+				`orders/bucket (max 63) and orders/bucket (max 63) both generate name "my-prod-stack-orders-bucket"`,
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			names, err := namer.New("my-prod-stack").NewResourceNameBatch(testCase.specs)
+			if err == nil {
+				t.Fatalf("NewResourceNameBatch() = %v, want an error", names)
+			}
+
+			for _, expected := range testCase.expectedErrors {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("NewResourceNameBatch() error = %v, want it to contain %q", err, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestNewResourceNameBatch_OrderIndependent(t *testing.T) {
+	t.Parallel()
+
+	n := namer.New("my-prod-stack")
+	specs := []namer.ResourceSpec{
+		{Name: "worker-11", Type: "pod", MaxLength: 12},
+		{Name: "orders", Type: "bucket-", MaxLength: 63},
+		{Name: "worker-10", Type: "pod", MaxLength: 12},
+		{Name: "orders", Type: "topic", MaxLength: 63},
+	}
+	reversed := slices.Clone(specs)
+	slices.Reverse(reversed)
+
+	_, err := n.NewResourceNameBatch(specs)
+	_, reversedErr := n.NewResourceNameBatch(reversed)
+
+	if err == nil || reversedErr == nil || err.Error() != reversedErr.Error() {
+		t.Errorf("NewResourceNameBatch() errors = %v and %v, want the same errors in any order", err, reversedErr)
+	}
+}
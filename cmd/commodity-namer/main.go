@@ -0,0 +1,13 @@
+// Command commodity-namer generates the same resource names as the namer
+// package, for shell scripts, Makefiles and non-Go tooling.
+package main
+
+import (
+	"os"
+
+	"github.com/davidmontoyago/commodity-namer/internal/cli"
+)
+
+func main() {
+	os.Exit(cli.Run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
@@ -0,0 +1,210 @@
+package namer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the Namer configuration of a YAML or JSON document, so one naming
+// convention can be shared across services. E.g.:
+//
+//	base: acme
+//	replace: true
+//	profile: gcp
+//	environment: production
+//	abbreviations:
+//	  service-account: sa
+//	limits:
+//	  storage-account: 24
+//	strategy: priority
+type Config struct {
+	// Base is the base name prefixed to every name.
+	Base string `yaml:"base"`
+	// Replace is WithReplace.
+	Replace bool `yaml:"replace"`
+	// Profile is the name of a well-known profile set with WithProfile.
+	Profile string `yaml:"profile"`
+	// Separator is WithSeparator. It is set after the profile.
+	Separator *string `yaml:"separator"`
+	// Environment is WithEnvironment.
+	Environment string `yaml:"environment"`
+	// Region is WithRegion.
+	Region string `yaml:"region"`
+	// Template is the layout parsed for WithTemplate.
+	Template string `yaml:"template"`
+	// Values are the template values set with WithValue.
+	Values map[string]string `yaml:"values"`
+	// Abbreviations is WithAbbreviations.
+	Abbreviations map[string]string `yaml:"abbreviations"`
+	// Limits is WithLimits.
+	Limits map[string]int `yaml:"limits"`
+	// Strategy is WithTruncation, either priority or proportional.
+	Strategy string `yaml:"strategy"`
+}
+
+// NewFromConfig creates a Namer from a YAML or JSON configuration document.
+// Unknown fields are rejected, and every invalid setting is reported at once.
+// Options are applied after those of the configuration.
+func NewFromConfig(reader io.Reader, opts ...Option) (Namer, error) {
+	var config Config
+	decoder := yaml.NewDecoder(reader)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return Namer{}, fmt.Errorf("failed to decode namer config: %w", err)
+	}
+
+	n, _, err := config.Namer(opts...)
+
+	return n, err
+}
+
+// NewFromConfigFile creates a Namer from a YAML or JSON configuration file.
+func NewFromConfigFile(path string, opts ...Option) (Namer, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return Namer{}, fmt.Errorf("failed to open namer config: %w", err)
+	}
+	defer file.Close()
+
+	return NewFromConfig(file, opts...)
+}
+
+// Environment variables read by NewFromEnv.
+const (
+	// EnvBase sets the base name.
+	EnvBase = "COMMODITY_NAMER_BASE"
+	// EnvEnvironment sets WithEnvironment. E.g.: production.
+	EnvEnvironment = "COMMODITY_NAMER_ENVIRONMENT"
+	// EnvRegion sets WithRegion. E.g.: us-central1.
+	EnvRegion = "COMMODITY_NAMER_REGION"
+	// EnvReplace sets WithReplace when true. E.g.: true, 1.
+	EnvReplace = "COMMODITY_NAMER_REPLACE"
+	// EnvProfile sets WithProfile with a well-known profile. E.g.: aws-s3.
+	EnvProfile = "COMMODITY_NAMER_PROFILE"
+)
+
+// NewFromEnv creates a Namer from the COMMODITY_NAMER_* environment variables,
+// so CI jobs and containers can set the naming context without code changes.
+// Unset variables are left out, and every invalid variable is reported at
+// once. Options are applied after those of the environment.
+func NewFromEnv(opts ...Option) (Namer, error) {
+	config := Config{
+		Base:        os.Getenv(EnvBase),
+		Environment: os.Getenv(EnvEnvironment),
+		Region:      os.Getenv(EnvRegion),
+		Profile:     os.Getenv(EnvProfile),
+	}
+
+	var errs []error
+	if value := os.Getenv(EnvReplace); value != "" {
+		replace, err := strconv.ParseBool(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s %q is not a boolean", EnvReplace, value))
+		}
+		config.Replace = replace
+	}
+
+	envOpts, err := config.Options()
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w", EnvProfile, err))
+	}
+
+	if len(errs) > 0 {
+		return Namer{}, errors.Join(errs...)
+	}
+
+	return New(config.Base, append(envOpts, opts...)...), nil
+}
+
+// Namer creates the Namer of the configuration, along with the max length of
+// names under its profile, or RFC 1035 without one. Options are applied after
+// those of the configuration.
+func (c Config) Namer(opts ...Option) (Namer, int, error) {
+	configOpts, err := c.Options()
+	if err != nil {
+		return Namer{}, 0, err
+	}
+
+	maxLength := profiles[ProfileGCP].MaxLength
+	if c.Profile != "" {
+		maxLength = profiles[c.Profile].MaxLength
+	}
+
+	return New(c.Base, append(configOpts, opts...)...), maxLength, nil
+}
+
+// Options returns the options of the configuration, or every invalid setting.
+func (c Config) Options() ([]Option, error) {
+	var errs []error
+	var opts []Option
+
+	if c.Replace {
+		opts = append(opts, WithReplace())
+	}
+
+	if c.Profile != "" {
+		profile, err := LookupProfile(c.Profile)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		opts = append(opts, WithProfile(profile))
+	}
+
+	if c.Separator != nil {
+		opts = append(opts, WithSeparator(*c.Separator))
+	}
+
+	if c.Environment != "" {
+		opts = append(opts, WithEnvironment(c.Environment))
+	}
+
+	if c.Region != "" {
+		opts = append(opts, WithRegion(c.Region))
+	}
+
+	if c.Template != "" {
+		template, err := ParseTemplate(c.Template)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		opts = append(opts, WithTemplate(template))
+	}
+
+	for _, key := range sortedKeys(c.Values) {
+		opts = append(opts, WithValue(key, c.Values[key]))
+	}
+
+	if len(c.Abbreviations) > 0 {
+		opts = append(opts, WithAbbreviations(c.Abbreviations))
+	}
+
+	for _, resourceType := range sortedKeys(c.Limits) {
+		if c.Limits[resourceType] <= 0 {
+			errs = append(errs, fmt.Errorf("limit of %q must be positive, got %d", resourceType, c.Limits[resourceType]))
+		}
+	}
+	if len(c.Limits) > 0 {
+		opts = append(opts, WithLimits(c.Limits))
+	}
+
+	switch c.Strategy {
+	case "":
+	case TruncationPriority, TruncationProportional:
+		opts = append(opts, WithTruncation(c.Strategy))
+	default:
+		errs = append(errs, fmt.Errorf("unknown strategy %q, expected one of %s, %s",
+			c.Strategy, TruncationPriority, TruncationProportional))
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return opts, nil
+}
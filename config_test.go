@@ -0,0 +1,299 @@
+package namer_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+)
+
+func TestNewFromConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		config       string
+		serviceName  string
+		resourceType string
+		maxLength    int
+		expected     string
+	}{
+		{
+			name: "yaml",
+			config: `
+base: acme
+replace: true
+environment: production
+region: us-central1
+abbreviations:
+  service-account: sa
+`,
+			serviceName:  "backend_processor",
+			resourceType: "service-account",
+			maxLength:    63,
+			expected:     "acme-prd-usc1-backend-processor-sa",
+		},
+		{
+			name:         "json",
+			config:       `{"base": "acme", "separator": "_", "template": "{type}-{name}-{base}"}`,
+			serviceName:  "orders",
+			resourceType: "bucket",
+			maxLength:    63,
+			expected:     "bucket_orders_acme",
+		},
+		{
+			name: "profile with a limit by resource type",
+			config: `
+base: enterprise
+profile: azure-storage
+replace: true
+abbreviations: {storage-account: st}
+limits: {st: 20}
+`,
+			serviceName:  "backup-archive-primary",
+			resourceType: "storage-account",
+			maxLength:    63,
+			expected:     "enterpbackuparchivs",
+		},
+		{
+			name:         "template values",
+			config:       "base: acme\ntemplate: '{org}-{name}-{type}'\nvalues: {org: platform}\n",
+			serviceName:  "orders",
+			resourceType: "bucket",
+			maxLength:    63,
+			expected:     "platform-orders-bucket",
+		},
+		{
+			name:         "proportional strategy",
+			config:       "base: cloudflare-edge-waf\nstrategy: proportional\n",
+			serviceName:  "zone",
+			resourceType: "dns",
+			maxLength:    15,
+			expected:     "cloudflare-zo-d",
+		},
+		{
+			name:         "empty",
+			config:       "",
+			serviceName:  "orders",
+			resourceType: "bucket",
+			maxLength:    63,
+			expected:     "orders-bucket",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			n, err := namer.NewFromConfig(strings.NewReader(testCase.config))
+			if err != nil {
+				t.Fatalf("NewFromConfig() error = %v", err)
+			}
+
+			result := n.NewResourceName(testCase.serviceName, testCase.resourceType, testCase.maxLength)
+			if result != testCase.expected {
+				t.Errorf("NewResourceName() = %v, want %v", result, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestNewFromConfig_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		config         string
+		expectedErrors []string
+	}{
+		{
+			name:           "unknown field",
+			config:         "base: acme\ntruncation: proportional\n",
+			expectedErrors: []string{"field truncation not found"},
+		},
+		{
+			name:   "every invalid setting is reported",
+			config: "profile: gcs\ntemplate: '{name'\nlimits: {bucket: 0}\nstrategy: random\n",
+			expectedErrors: []string{
+				`unknown profile "gcs"`,
+				`template "{name" has an unclosed '{'`,
+				`limit of "bucket" must be positive, got 0`,
+				`unknown strategy "random", expected one of priority, proportional`,
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := namer.NewFromConfig(strings.NewReader(testCase.config))
+			if err == nil {
+				t.Fatalf("NewFromConfig() expected an error")
+			}
+
+			for _, expected := range testCase.expectedErrors {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("NewFromConfig() error = %v, want it to contain %q", err, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestNewFromConfigFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "namer.yaml")
+	if err := os.WriteFile(path, []byte("base: acme\nenvironment: staging\n"), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	n, err := namer.NewFromConfigFile(path, namer.WithRegion("us-east-1"))
+	if err != nil {
+		t.Fatalf("NewFromConfigFile() error = %v", err)
+	}
+
+	if result := n.NewResourceName("orders", "bucket", 63); result != "acme-stg-ue1-orders-bucket" {
+		t.Errorf("NewResourceName() = %v, want acme-stg-ue1-orders-bucket", result)
+	}
+
+	if _, err := namer.NewFromConfigFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Errorf("NewFromConfigFile() expected an error for a missing file")
+	}
+}
+
+func TestConfig_Namer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		config            namer.Config
+		expectedName      string
+		expectedMaxLength int
+	}{
+		{
+			name:              "RFC 1035 without a profile",
+			config:            namer.Config{Base: "acme"},
+			expectedName:      "acme-orders-bucket",
+			expectedMaxLength: 63,
+		},
+		{
+			name:              "profile max length",
+			config:            namer.Config{Base: "acme", Profile: namer.ProfileAzureStorage},
+			expectedName:      "acmeordersbucket",
+			expectedMaxLength: 24,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			n, maxLength, err := testCase.config.Namer()
+			if err != nil {
+				t.Fatalf("Namer() error = %v", err)
+			}
+
+			if maxLength != testCase.expectedMaxLength {
+				t.Errorf("Namer() max length = %d, want %d", maxLength, testCase.expectedMaxLength)
+			}
+
+			if result := n.NewResourceName("orders", "bucket", maxLength); result != testCase.expectedName {
+				t.Errorf("NewResourceName() = %v, want %v", result, testCase.expectedName)
+			}
+		})
+	}
+}
+
+func TestNewResourceName_WithLimits(t *testing.T) {
+	t.Parallel()
+
+	n := namer.New("enterprise-platform", namer.WithLimits(map[string]int{"vault": 24}))
+
+	if result := n.NewResourceName("secrets", "vault", 63); len(result) > 24 {
+		t.Errorf("NewResourceName() = %v, want at most 24 characters", result)
+	}
+
+	if result := n.NewResourceName("secrets", "bucket", 63); result != "enterprise-platform-secrets-bucket" {
+		t.Errorf("NewResourceName() = %v, want enterprise-platform-secrets-bucket", result)
+	}
+}
+
+func TestNewResourceName_WithLimitsAfterReplace(t *testing.T) {
+	t.Parallel()
+
+	n := namer.New("enterprise-platform", namer.WithReplace(), namer.WithLimits(map[string]int{"storage-account": 24}))
+
+	if result := n.NewResourceName("backup-archive", "Storage_Account", 63); len(result) > 24 {
+		t.Errorf("NewResourceName() = %v, want at most 24 characters", result)
+	}
+}
+
+//nolint:paralleltest // t.Setenv doesn't support parallel tests
+func TestNewFromEnv(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         map[string]string
+		serviceName string
+		expected    string
+	}{
+		{
+			name: "every variable",
+			env: map[string]string{
+				namer.EnvBase:        "acme",
+				namer.EnvEnvironment: "production",
+				namer.EnvRegion:      "us-east-1",
+				namer.EnvReplace:     "true",
+				namer.EnvProfile:     "kubernetes",
+			},
+			serviceName: "Orders_API",
+			expected:    "acme-prd-ue1-orders-api-bucket",
+		},
+		{
+			name:        "no variables",
+			env:         map[string]string{},
+			serviceName: "orders",
+			expected:    "orders-bucket",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			for _, key := range []string{namer.EnvBase, namer.EnvEnvironment, namer.EnvRegion, namer.EnvReplace, namer.EnvProfile} {
+				t.Setenv(key, testCase.env[key])
+			}
+
+			n, err := namer.NewFromEnv()
+			if err != nil {
+				t.Fatalf("NewFromEnv() error = %v", err)
+			}
+
+			if result := n.NewResourceName(testCase.serviceName, "bucket", 63); result != testCase.expected {
+				t.Errorf("NewResourceName() = %v, want %v", result, testCase.expected)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // t.Setenv doesn't support parallel tests
+func TestNewFromEnv_Invalid(t *testing.T) {
+	t.Setenv(namer.EnvReplace, "maybe")
+	t.Setenv(namer.EnvProfile, "gcs")
+
+	_, err := namer.NewFromEnv()
+	if err == nil {
+		t.Fatalf("NewFromEnv() expected an error")
+	}
+
+	for _, expected := range []string{
+		`COMMODITY_NAMER_REPLACE "maybe" is not a boolean`,
+		`COMMODITY_NAMER_PROFILE: unknown profile "gcs"`,
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("NewFromEnv() error = %v, want it to contain %q", err, expected)
+		}
+	}
+}
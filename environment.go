@@ -0,0 +1,67 @@
+package namer
+
+import "strings"
+
+// environmentAbbreviations maps common environment names to their standard
+// abbreviation.
+var environmentAbbreviations = map[string]string{
+	"production":        "prd",
+	"prod":              "prd",
+	"prd":               "prd",
+	"staging":           "stg",
+	"stage":             "stg",
+	"stg":               "stg",
+	"development":       "dev",
+	"develop":           "dev",
+	"dev":               "dev",
+	"testing":           "tst",
+	"test":              "tst",
+	"tst":               "tst",
+	"quality-assurance": "qa",
+	"qa":                "qa",
+	"user-acceptance":   "uat",
+	"uat":               "uat",
+	"pre-production":    "ppd",
+	"preproduction":     "ppd",
+	"preprod":           "ppd",
+	"ppd":               "ppd",
+	"integration":       "int",
+	"int":               "int",
+	"sandbox":           "sbx",
+	"sbx":               "sbx",
+	"performance":       "prf",
+	"perf":              "prf",
+	"prf":               "prf",
+	"disaster-recovery": "dr",
+	"dr":                "dr",
+	"demo":              "dmo",
+	"dmo":               "dmo",
+	"local":             "loc",
+	"loc":               "loc",
+}
+
+// AbbreviateEnvironment returns the standard abbreviation of an environment.
+// E.g.: production→prd, staging→stg, development→dev. Unknown environments
+// are returned in lowercase as is.
+func AbbreviateEnvironment(environment string) string {
+	environment = strings.ToLower(strings.TrimSpace(environment))
+	if abbreviation, ok := environmentAbbreviations[environment]; ok {
+		return abbreviation
+	}
+
+	return environment
+}
+
+// WithEnvironment adds the abbreviated environment to every name, right after
+// the base name or wherever a template sets {env}. The environment is never
+// truncated, so names from different environments can't be confused.
+func WithEnvironment(environment string) Option {
+	return func(n *Namer) {
+		n.environment = AbbreviateEnvironment(environment)
+	}
+}
+
+// environmentSegment returns the environment as a fixed segment.
+func (e Namer) environmentSegment() Segment {
+	return Segment{Name: SegmentEnvironment, Value: e.environment, Fixed: true}
+}
@@ -0,0 +1,140 @@
+package namer_test
+
+import (
+	"testing"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+)
+
+func TestAbbreviateEnvironment(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		environment string
+		expected    string
+	}{
+		{environment: "production", expected: "prd"},
+		{environment: "prod", expected: "prd"},
+		{environment: "Production", expected: "prd"},
+		{environment: "staging", expected: "stg"},
+		{environment: "development", expected: "dev"},
+		{environment: "test", expected: "tst"},
+		{environment: "sandbox", expected: "sbx"},
+		{environment: " qa ", expected: "qa"},
+		{environment: "prd", expected: "prd"},
+		{environment: "Canary", expected: "canary"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.environment, func(t *testing.T) {
+			t.Parallel()
+
+			result := namer.AbbreviateEnvironment(testCase.environment)
+			if result != testCase.expected {
+				t.Errorf("AbbreviateEnvironment() = %v, want %v", result, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestNewResourceName_WithEnvironment(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		baseName     string
+		environment  string
+		template     string
+		serviceName  string
+		resourceType string
+		maxLength    int
+		expected     string
+	}{
+		{
+			name:         "environment after base name",
+			baseName:     "my-prod-stack",
+			environment:  "production",
+			serviceName:  "orders",
+			resourceType: "bucket",
+			maxLength:    63,
+			expected:     "my-prod-stack-prd-orders-bucket",
+		},
+		{
+			name:         "environment is not truncated with base name",
+			baseName:     "cloudflare-edge-waf",
+			environment:  "staging",
+			serviceName:  "zone",
+			resourceType: "dns",
+			maxLength:    19,
+			expected:     "cloudf-stg-zone-dns",
+		},
+		{
+			name:         "environment is not truncated proportionally",
+			baseName:     "my-prod-stack",
+			environment:  "Production",
+			serviceName:  "backend-processor",
+			resourceType: "service-account",
+			maxLength:    30,
+			expected:     "my-prod-prd-backend-p-service",
+		},
+		{
+			name:         "environment survives a very short limit",
+			baseName:     "fullstack",
+			environment:  "staging",
+			serviceName:  "frontend",
+			resourceType: "account",
+			maxLength:    10,
+			expected:     "f-stg-fr-a",
+		},
+		{
+			name:         "environment placed by template",
+			baseName:     "my-prod-stack",
+			environment:  "development",
+			template:     "{type}-{name}-{env}",
+			serviceName:  "orders",
+			resourceType: "st",
+			maxLength:    24,
+			expected:     "st-orders-dev",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := []namer.Option{namer.WithEnvironment(testCase.environment)}
+			if testCase.template != "" {
+				opts = append(opts, namer.WithTemplate(namer.MustParseTemplate(testCase.template)))
+			}
+
+			n := namer.New(testCase.baseName, opts...)
+			result := n.NewResourceName(testCase.serviceName, testCase.resourceType, testCase.maxLength)
+
+			if result != testCase.expected {
+				t.Errorf("NewResourceName() = %v, want %v", result, testCase.expected)
+			}
+
+			if len(result) > testCase.maxLength {
+				t.Errorf("NewResourceName() length = %d, want <= %d", len(result), testCase.maxLength)
+			}
+		})
+	}
+}
+
+func TestNewResourceName_WithEnvironmentMissingFromTemplate(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic but none occurred")
+		}
+	}()
+
+	n := namer.New("stack",
+		namer.WithEnvironment("production"),
+		namer.WithTemplate(namer.MustParseTemplate("{base}-{name}-{type?}")),
+	)
+	result := n.NewResourceName("orders", "bucket", 63)
+
+	t.Errorf("Expected panic but got result: %s", result)
+}
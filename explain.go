@@ -0,0 +1,132 @@
+package namer
+
+// Truncation strategies recorded in an Explanation.
+const (
+	// TruncationNone means the name fit in the max length as is.
+	TruncationNone = "none"
+	// TruncationPriority means the segments with the highest priority, the base
+	// name first, absorbed the surplus on their own.
+	TruncationPriority = "priority"
+	// TruncationProportional means every segment was truncated by the same
+	// factor.
+	TruncationProportional = "proportional"
+)
+
+// Explanation traces how a name was derived, so naming decisions can be
+// debugged and reviewed.
+type Explanation struct {
+	// Name is the generated name. It is set even when the name is not valid.
+	Name string `json:"name"`
+	// MaxLength is the max length, capped by the limit of the resource type.
+	MaxLength int `json:"maxLength"`
+	// Components are the name components in order, from input to output.
+	Components []ComponentTrace `json:"components"`
+	// Replacements are the segments changed by WithReplace and
+	// WithAbbreviations.
+	Replacements []Replacement `json:"replacements,omitempty"`
+	// Surplus is the number of characters over the max length.
+	Surplus int `json:"surplus"`
+	// Truncation is the strategy taken to remove the surplus. E.g.:
+	// TruncationPriority.
+	Truncation string `json:"truncation"`
+	// Factor is the truncation factor of TruncationProportional.
+	Factor float64 `json:"factor,omitempty"`
+	// Valid reports whether the name passed validation.
+	Valid bool `json:"valid"`
+	// Error is the reason the name is not valid.
+	Error string `json:"error,omitempty"`
+
+	segments []Segment
+	err      error
+}
+
+// ComponentTrace traces a name component from input to output.
+type ComponentTrace struct {
+	// Segment is the segment name. It is empty for template literals.
+	Segment string `json:"segment,omitempty"`
+	// Input is the value before replacements.
+	Input string `json:"input"`
+	// Value is the value before truncation.
+	Value string `json:"value"`
+	// Output is the value in the generated name.
+	Output string `json:"output"`
+	// Length is the length before truncation.
+	Length int `json:"length"`
+	// OutputLength is the length in the generated name.
+	OutputLength int `json:"outputLength"`
+	// Priority is the truncation priority.
+	Priority int `json:"priority"`
+	// Fixed reports whether the component is never truncated.
+	Fixed bool `json:"fixed"`
+}
+
+// Replacement records a segment changed by WithReplace or WithAbbreviations.
+type Replacement struct {
+	Segment string `json:"segment"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+}
+
+// Explain traces how NewResourceName derives a name. Unlike NewResourceName it
+// doesn't panic; validation errors are recorded in the explanation.
+func (e Namer) Explain(resourceName, resourceType string, maxLength int) Explanation {
+	return e.ExplainName(maxLength,
+		Segment{Name: SegmentName, Value: resourceName},
+		Segment{Name: SegmentType, Value: resourceType},
+	)
+}
+
+// ExplainName traces how NewName derives a name from the segments.
+func (e Namer) ExplainName(maxLength int, segments ...Segment) Explanation {
+	return e.explain(maxLength, segments)
+}
+
+// fail records why the name is not valid.
+func (x *Explanation) fail(err error) {
+	x.err = err
+	x.Error = err.Error()
+}
+
+// replacements returns the segments changed by replacements.
+func replacements(inputs, replaced []Segment) []Replacement {
+	var changed []Replacement
+	for i, segment := range replaced {
+		if segment.Value != inputs[i].Value {
+			changed = append(changed, Replacement{Segment: segment.Name, From: inputs[i].Value, To: segment.Value})
+		}
+	}
+
+	return changed
+}
+
+// components traces the laid out segments to their inputs and outputs.
+// Segments added by the Namer, such as the base name, are their own input.
+func components(inputs, laidOut, truncated []Segment) []ComponentTrace {
+	inputValues := make(map[string]string, len(inputs))
+	for _, input := range inputs {
+		if _, ok := inputValues[input.Name]; !ok {
+			inputValues[input.Name] = input.Value
+		}
+	}
+
+	traces := make([]ComponentTrace, len(laidOut))
+	for i, segment := range laidOut {
+		input, ok := inputValues[segment.Name]
+		if !ok || segment.Name == "" {
+			input = segment.Value
+		}
+
+		traces[i] = ComponentTrace{
+			Segment:      segment.Name,
+			Input:        input,
+			Value:        segment.Value,
+			Output:       truncated[i].Value,
+			Length:       len(segment.Value),
+			OutputLength: len(truncated[i].Value),
+			Priority:     segment.Priority,
+			Fixed:        segment.Fixed,
+		}
+	}
+
+	return traces
+}
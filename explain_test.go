@@ -0,0 +1,185 @@
+package namer_test
+
+import (
+	"slices"
+	"testing"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+)
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		baseName           string
+		opts               []namer.Option
+		serviceName        string
+		resourceType       string
+		maxLength          int
+		expectedName       string
+		expectedSurplus    int
+		expectedTruncation string
+		expectedFactor     float64
+		expectedOutputs    []string
+	}{
+		{
+			name:               "no truncation",
+			baseName:           "my-prod-stack",
+			serviceName:        "orders",
+			resourceType:       "bucket",
+			maxLength:          63,
+			expectedName:       "my-prod-stack-orders-bucket",
+			expectedTruncation: namer.TruncationNone,
+			expectedOutputs:    []string{"my-prod-stack", "orders", "bucket"},
+		},
+		{
+			name:               "base name absorbs the surplus",
+			baseName:           "cloudflare-edge-waf",
+			serviceName:        "zone",
+			resourceType:       "dns",
+			maxLength:          15,
+			expectedName:       "cloudf-zone-dns",
+			expectedSurplus:    13,
+			expectedTruncation: namer.TruncationPriority,
+			expectedOutputs:    []string{"cloudf", "zone", "dns"},
+		},
+		{
+			name:               "proportional truncation",
+			baseName:           "fullstack-app",
+			serviceName:        "frontend",
+			resourceType:       "secret-access",
+			maxLength:          19,
+			expectedName:       "fullst-fron-secret",
+			expectedSurplus:    17,
+			expectedTruncation: namer.TruncationProportional,
+			expectedFactor:     0.52,
+			expectedOutputs:    []string{"fullst", "fron", "secret"},
+		},
+		{
+			name:               "proportional strategy",
+			baseName:           "cloudflare-edge-waf",
+			opts:               []namer.Option{namer.WithTruncation(namer.TruncationProportional)},
+			serviceName:        "zone",
+			resourceType:       "dns",
+			maxLength:          15,
+			expectedName:       "cloudflare-zo-d",
+			expectedSurplus:    13,
+			expectedTruncation: namer.TruncationProportional,
+			expectedFactor:     0.53,
+			expectedOutputs:    []string{"cloudflare", "zo", "d"},
+		},
+		{
+			name:               "environment kept",
+			baseName:           "my-prod-stack",
+			opts:               []namer.Option{namer.WithEnvironment("production")},
+			serviceName:        "orders",
+			resourceType:       "bucket",
+			maxLength:          20,
+			expectedName:       "my-prd-orders-bucket",
+			expectedSurplus:    11,
+			expectedTruncation: namer.TruncationPriority,
+			expectedOutputs:    []string{"my", "prd", "orders", "bucket"},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			n := namer.New(testCase.baseName, testCase.opts...)
+			explanation := n.Explain(testCase.serviceName, testCase.resourceType, testCase.maxLength)
+
+			if explanation.Name != testCase.expectedName {
+				t.Errorf("Explain() name = %v, want %v", explanation.Name, testCase.expectedName)
+			}
+
+			if name := n.NewResourceName(testCase.serviceName, testCase.resourceType, testCase.maxLength); explanation.Name != name {
+				t.Errorf("Explain() name = %v, want the NewResourceName() name %v", explanation.Name, name)
+			}
+
+			if explanation.Surplus != testCase.expectedSurplus {
+				t.Errorf("Explain() surplus = %d, want %d", explanation.Surplus, testCase.expectedSurplus)
+			}
+
+			if explanation.Truncation != testCase.expectedTruncation {
+				t.Errorf("Explain() truncation = %v, want %v", explanation.Truncation, testCase.expectedTruncation)
+			}
+
+			if explanation.Factor != testCase.expectedFactor {
+				t.Errorf("Explain() factor = %v, want %v", explanation.Factor, testCase.expectedFactor)
+			}
+
+			outputs := make([]string, len(explanation.Components))
+			for i, component := range explanation.Components {
+				outputs[i] = component.Output
+
+				if component.OutputLength != len(component.Output) || component.Length != len(component.Value) {
+					t.Errorf("Explain() component %+v lengths don't match its values", component)
+				}
+			}
+
+			if !slices.Equal(outputs, testCase.expectedOutputs) {
+				t.Errorf("Explain() outputs = %v, want %v", outputs, testCase.expectedOutputs)
+			}
+
+			if !explanation.Valid || explanation.Error != "" {
+				t.Errorf("Explain() valid = %v, error = %v, want a valid name", explanation.Valid, explanation.Error)
+			}
+		})
+	}
+}
+
+func TestExplain_Replacements(t *testing.T) {
+	t.Parallel()
+
+	n := namer.New("my-prod-stack", namer.WithReplace())
+	explanation := n.Explain("backend_processor", "service.account", 63)
+
+	expected := []namer.Replacement{
+		{Segment: namer.SegmentName, From: "backend_processor", To: "backend-processor"},
+		{Segment: namer.SegmentType, From: "service.account", To: "service-account"},
+	}
+	if !slices.Equal(explanation.Replacements, expected) {
+		t.Errorf("Explain() replacements = %+v, want %+v", explanation.Replacements, expected)
+	}
+
+	if component := explanation.Components[1]; component.Input != "backend_processor" || component.Value != "backend-processor" {
+		t.Errorf("Explain() component = %+v, want input backend_processor and value backend-processor", component)
+	}
+}
+
+func TestExplain_InvalidName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		baseName      string
+		opts          []namer.Option
+		expectedError string
+	}{
+		{
+			name:          "name starts with an uppercase letter",
+			baseName:      "Invalid",
+			expectedError: "name must start with a lowercase letter",
+		},
+		{
+			name:          "template without a placeholder for the environment",
+			baseName:      "stack",
+			opts:          []namer.Option{namer.WithTemplate(namer.MustParseTemplate("{base}-{name}-{type}")), namer.WithEnvironment("production")},
+			expectedError: `template "{base}-{name}-{type}" has no placeholder for "env"`,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			explanation := namer.New(testCase.baseName, testCase.opts...).Explain("orders", "bucket", 63)
+
+			if explanation.Valid || explanation.Error != testCase.expectedError {
+				t.Errorf("Explain() valid = %v, error = %v, want error %v", explanation.Valid, explanation.Error, testCase.expectedError)
+			}
+		})
+	}
+}
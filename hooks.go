@@ -0,0 +1,92 @@
+package namer
+
+import (
+	"fmt"
+)
+
+// Hooks receives naming events, e.g. to count how often names are truncated.
+// Hooks are called synchronously and may be called concurrently when the
+// Namer is shared. Embed NoopHooks to implement only some of them.
+type Hooks interface {
+	// NameGenerated is called with every name returned to the caller, once it
+	// passed validation and collision checks.
+	NameGenerated(name string)
+	// NameTruncated is called when a name is over the max length. Ratio is the
+	// fraction of the untruncated length that was kept, e.g. 0.4 when the name
+	// lost 60% of its characters.
+	NameTruncated(name, untruncated string, ratio float64)
+	// ComponentDropped is called when truncation leaves nothing of a segment.
+	ComponentDropped(name, segment, value string)
+	// CollisionDetected is called when two inputs generate the same name, e.g.
+	// two specs of a batch.
+	CollisionDetected(name, first, second string)
+	// ValidationFailed is called when a name is not valid.
+	ValidationFailed(name string, err error)
+}
+
+// NoopHooks ignores every event.
+type NoopHooks struct{}
+
+// NameGenerated does nothing.
+func (NoopHooks) NameGenerated(string) {}
+
+// NameTruncated does nothing.
+func (NoopHooks) NameTruncated(string, string, float64) {}
+
+// ComponentDropped does nothing.
+func (NoopHooks) ComponentDropped(string, string, string) {}
+
+// CollisionDetected does nothing.
+func (NoopHooks) CollisionDetected(string, string, string) {}
+
+// ValidationFailed does nothing.
+func (NoopHooks) ValidationFailed(string, error) {}
+
+// WithHooks registers hooks for naming events. Explain doesn't trigger them.
+func WithHooks(hooks Hooks) Option {
+	return func(n *Namer) {
+		n.hooks = hooks
+	}
+}
+
+// notify sends the truncation and validation events of a name to the hooks.
+func (e Namer) notify(explanation Explanation) {
+	if e.hooks == nil {
+		return
+	}
+
+	if explanation.Surplus > 0 {
+		untruncated := e.join(segmentValues(explanation.segments)...)
+		e.hooks.NameTruncated(explanation.Name, untruncated, float64(len(explanation.Name))/float64(len(untruncated)))
+
+		for _, component := range explanation.Components {
+			if component.Value != "" && component.Output == "" {
+				e.hooks.ComponentDropped(explanation.Name, component.Segment, component.Value)
+			}
+		}
+	}
+
+	if explanation.err != nil {
+		e.hooks.ValidationFailed(explanation.Name, explanation.err)
+	}
+}
+
+// notifyGenerated sends the names returned to the caller to the hooks.
+func (e Namer) notifyGenerated(names ...string) {
+	if e.hooks == nil {
+		return
+	}
+
+	for _, name := range names {
+		e.hooks.NameGenerated(name)
+	}
+}
+
+// notifyCollision sends a collision to the hooks and returns it as an error.
+func (e Namer) notifyCollision(name, first, second string) error {
+	if e.hooks != nil {
+		e.hooks.CollisionDetected(name, first, second)
+	}
+
+	return fmt.Errorf("%s and %s both generate name %q", first, second, name)
+}
@@ -0,0 +1,152 @@
+package namer_test
+
+import (
+	"strings"
+	"testing"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+)
+
+// recordingHooks records every event as a string.
+type recordingHooks struct {
+	namer.NoopHooks
+
+	events []string
+}
+
+func (r *recordingHooks) NameGenerated(name string) {
+	r.events = append(r.events, "generated "+name)
+}
+
+func (r *recordingHooks) NameTruncated(name, untruncated string, ratio float64) {
+	r.events = append(r.events, "truncated "+untruncated+" to "+name)
+	if ratio <= 0 || ratio >= 1 {
+		r.events = append(r.events, "ratio out of range")
+	}
+}
+
+func (r *recordingHooks) ComponentDropped(name, segment, value string) {
+	r.events = append(r.events, "dropped "+segment+" "+value+" from "+name)
+}
+
+func (r *recordingHooks) CollisionDetected(name, first, second string) {
+	r.events = append(r.events, "collision "+first+" and "+second+" on "+name)
+}
+
+func (r *recordingHooks) ValidationFailed(name string, err error) {
+	r.events = append(r.events, "failed "+name+": "+err.Error())
+}
+
+func TestWithHooks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		baseName       string
+		generate       func(n namer.Namer)
+		expectedEvents []string
+	}{
+		{
+			name:     "name generated",
+			baseName: "my-prod-stack",
+			generate: func(n namer.Namer) {
+				n.NewResourceName("orders", "bucket", 63)
+			},
+			expectedEvents: []string{"generated my-prod-stack-orders-bucket"},
+		},
+		{
+			name:     "name truncated",
+			baseName: "my-prod-stack",
+			generate: func(n namer.Namer) {
+				n.NewResourceName("backend-processor", "service-account", 30)
+			},
+			expectedEvents: []string{
+				"truncated my-prod-stack-backend-processor-service-account to my-prod-backend-pr-service-a",
+				"generated my-prod-backend-pr-service-a",
+			},
+		},
+		{
+			name:     "component dropped",
+			baseName: "my-prod-stack",
+			generate: func(n namer.Namer) {
+				n.NewResourceName("backend-processor", "x", 12)
+			},
+			expectedEvents: []string{
+				"truncated my-prod-stack-backend-processor-x to my-p-backen",
+				"dropped type x from my-p-backen",
+				"generated my-p-backen",
+			},
+		},
+		{
+			name:     "collision detected",
+			baseName: "my-prod-stack",
+			generate: func(n namer.Namer) {
+				_, _ = n.NewResourceNameBatch([]namer.ResourceSpec{
+					{Name: "worker-10", Type: "pod", MaxLength: 12},
+					{Name: "worker-11", Type: "pod", MaxLength: 12},
+				})
+			},
+			expectedEvents: []string{
+				"truncated my-prod-stack-worker-10-pod to my-pr-wor-p",
+				"truncated my-prod-stack-worker-11-pod to my-pr-wor-p",
+				"collision worker-10/pod (max 12) and worker-11/pod (max 12) on my-pr-wor-p",
+			},
+		},
+		{
+			name:     "batch names generated once returned",
+			baseName: "my-prod-stack",
+			generate: func(n namer.Namer) {
+				_, _ = n.NewResourceNameBatch([]namer.ResourceSpec{
+					{Name: "users", Type: "table", MaxLength: 63},
+					{Name: "orders", Type: "table", MaxLength: 63},
+				})
+			},
+			expectedEvents: []string{
+				"generated my-prod-stack-users-table",
+				"generated my-prod-stack-orders-table",
+			},
+		},
+		{
+			name:     "indexed names generated once distinct",
+			baseName: "my-prod-stack",
+			generate: func(n namer.Namer) {
+				n.NewResourceNames("worker", "pod", 2, 63)
+			},
+			expectedEvents: []string{
+				"generated my-prod-stack-worker-0-pod",
+				"generated my-prod-stack-worker-1-pod",
+			},
+		},
+		{
+			name:     "validation failed",
+			baseName: "Invalid",
+			generate: func(n namer.Namer) {
+				_, _ = n.NewResourceNameBatch([]namer.ResourceSpec{{Name: "orders", Type: "bucket", MaxLength: 63}})
+			},
+			expectedEvents: []string{
+				"failed Invalid-orders-bucket: name must start with a lowercase letter",
+			},
+		},
+		{
+			name:     "explain doesn't trigger hooks",
+			baseName: "my-prod-stack",
+			generate: func(n namer.Namer) {
+				n.Explain("backend-processor", "service-account", 30)
+			},
+			expectedEvents: nil,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			hooks := &recordingHooks{}
+			testCase.generate(namer.New(testCase.baseName, namer.WithHooks(hooks)))
+
+			if strings.Join(hooks.events, "\n") != strings.Join(testCase.expectedEvents, "\n") {
+				t.Errorf("events = %q, want %q", hooks.events, testCase.expectedEvents)
+			}
+		})
+	}
+}
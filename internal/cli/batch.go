@@ -0,0 +1,342 @@
+package cli
+
+import (
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+)
+
+// Spec formats.
+const (
+	formatYAML = "yaml"
+	formatJSON = "json"
+	formatCSV  = "csv"
+)
+
+// Output formats besides JSON and CSV.
+const (
+	formatDotenv = "dotenv"
+	formatTfvars = "tfvars"
+)
+
+// spec is a resource spec of a specs file.
+type spec struct {
+	// Key identifies the name in the output. Defaults to the name and type.
+	Key       string `yaml:"key"`
+	Name      string `yaml:"name"`
+	Type      string `yaml:"type"`
+	MaxLength int    `yaml:"maxLength"`
+}
+
+// result is a generated name in the JSON output.
+type result struct {
+	Key          string `json:"key"`
+	Name         string `json:"name"`
+	ResourceName string `json:"resourceName"`
+	ResourceType string `json:"resourceType,omitempty"`
+	MaxLength    int    `json:"maxLength"`
+}
+
+// resultWriters write the generated names by output format.
+var resultWriters = map[string]func(writer io.Writer, results []result, variable string) error{
+	formatJSON:   writeResultsJSON,
+	formatCSV:    writeResultsCSV,
+	formatDotenv: writeResultsDotenv,
+	formatTfvars: writeResultsTfvars,
+}
+
+// batch generates the names of every spec of a YAML, JSON or CSV file.
+func batch(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet(program+" batch", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	var namerFlags namerFlags
+	namerFlags.register(flags)
+	specsPath := flags.String("specs", "-", "specs file, or - for stdin")
+	specsFormat := flags.String("specs-format", "", "specs format, one of yaml, json, csv (default from the file extension, or yaml)")
+	outputFormat := flags.String("format", formatJSON, "output format, one of json, csv, dotenv, tfvars")
+	variable := flags.String("tfvars-variable", "names", "Terraform variable holding the names with -format tfvars")
+
+	if err := flags.Parse(args); err != nil {
+		return ExitUsage
+	}
+
+	writeResults, ok := resultWriters[*outputFormat]
+	if !ok {
+		return usageError(stderr, flags, fmt.Errorf("unknown output format %q", *outputFormat))
+	}
+
+	n, profileMaxLength, err := namerFlags.namer()
+	if err != nil {
+		return usageError(stderr, flags, err)
+	}
+
+	input, err := openInput(*specsPath, stdin)
+	if err != nil {
+		return usageError(stderr, flags, err)
+	}
+	defer input.Close()
+
+	format := *specsFormat
+	if format == "" {
+		format = specsFormatOf(*specsPath)
+	}
+
+	// specs that can't be read are reported along with those not valid
+	specs, readErr := readSpecs(input, format)
+	if specs == nil && readErr != nil {
+		return reportErrors(stderr, readErr)
+	}
+
+	results, err := generateBatch(n, specs, profileMaxLength)
+	if err := errors.Join(readErr, err); err != nil {
+		return reportErrors(stderr, err)
+	}
+
+	if err := writeResults(stdout, results, *variable); err != nil {
+		return reportErrors(stderr, err)
+	}
+
+	return ExitOK
+}
+
+// openInput opens the file, or stdin for "-".
+func openInput(path string, stdin io.Reader) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(stdin), nil
+	}
+
+	return os.Open(filepath.Clean(path))
+}
+
+// specsFormatOf returns the specs format of the file extension.
+func specsFormatOf(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return formatJSON
+	case ".csv":
+		return formatCSV
+	default:
+		return formatYAML
+	}
+}
+
+// readSpecs decodes a list of specs. JSON is decoded as YAML, which is a
+// superset of JSON.
+func readSpecs(input io.Reader, format string) ([]spec, error) {
+	switch format {
+	case formatYAML, formatJSON:
+		var specs []spec
+		decoder := yaml.NewDecoder(input)
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&specs); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to decode specs: %w", err)
+		}
+
+		return specs, nil
+	case formatCSV:
+		return readCSVSpecs(input)
+	default:
+		return nil, fmt.Errorf("unknown specs format %q", format)
+	}
+}
+
+// readCSVSpecs decodes specs from CSV with a header of key, name, type and
+// maxLength columns in any order. Only the name column is required. Rows with
+// a max length that is not a number are returned along with the error.
+func readCSVSpecs(input io.Reader) ([]spec, error) {
+	reader := csv.NewReader(input)
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode specs: %w", err)
+	}
+
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	for _, column := range header {
+		if !slices.Contains([]string{"key", "name", "type", "maxLength"}, column) {
+			return nil, fmt.Errorf("line 1: unknown column %q", column)
+		}
+	}
+
+	if !slices.Contains(header, "name") {
+		return nil, errors.New("line 1: missing column \"name\"")
+	}
+
+	var errs []error
+	specs := make([]spec, 0, len(records)-1)
+	for i, record := range records[1:] {
+		var resource spec
+		for j, column := range header {
+			switch column {
+			case "key":
+				resource.Key = record[j]
+			case "name":
+				resource.Name = record[j]
+			case "type":
+				resource.Type = record[j]
+			case "maxLength":
+				if record[j] == "" {
+					continue
+				}
+				if resource.MaxLength, err = strconv.Atoi(record[j]); err != nil {
+					errs = append(errs, fmt.Errorf("line %d: maxLength %q is not a number", i+2, record[j]))
+				}
+			}
+		}
+		specs = append(specs, resource)
+	}
+
+	return specs, errors.Join(errs...)
+}
+
+// generateBatch generates the names of the specs. Specs without a max length
+// take the profile max length. Every invalid spec is reported at once.
+func generateBatch(n namer.Namer, specs []spec, profileMaxLength int) ([]result, error) {
+	var errs []error
+	keys := make(map[string]bool, len(specs))
+	resources := make([]namer.ResourceSpec, len(specs))
+	for i, resource := range specs {
+		if resource.Name == "" {
+			errs = append(errs, fmt.Errorf("spec %d: name is required", i+1))
+		}
+
+		if resource.Key == "" {
+			specs[i].Key = strings.Trim(resource.Name+"-"+resource.Type, "-")
+		}
+
+		if keys[specs[i].Key] {
+			errs = append(errs, fmt.Errorf("spec %d: duplicate key %q", i+1, specs[i].Key))
+		}
+		keys[specs[i].Key] = true
+
+		if resource.MaxLength <= 0 {
+			specs[i].MaxLength = profileMaxLength
+		}
+		resources[i] = namer.ResourceSpec{Name: resource.Name, Type: resource.Type, MaxLength: specs[i].MaxLength}
+	}
+
+	names, err := n.NewResourceNameBatch(resources)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	results := make([]result, len(specs))
+	for i, resource := range specs {
+		results[i] = result{
+			Key:          resource.Key,
+			Name:         names[i],
+			ResourceName: resource.Name,
+			ResourceType: resource.Type,
+			MaxLength:    resource.MaxLength,
+		}
+	}
+
+	return results, nil
+}
+
+// reportErrors writes every error on its own line.
+func reportErrors(stderr io.Writer, err error) int {
+	for line := range strings.SplitSeq(err.Error(), "\n") {
+		fmt.Fprintf(stderr, "%s: %s\n", program, line)
+	}
+
+	return ExitInvalid
+}
+
+// writeResultsJSON writes the results as a JSON array.
+func writeResultsJSON(writer io.Writer, results []result, _ string) error {
+	if results == nil {
+		results = []result{}
+	}
+
+	return writeJSON(writer, results)
+}
+
+// writeResultsCSV writes the keys and names as CSV with a header.
+func writeResultsCSV(writer io.Writer, results []result, _ string) error {
+	csvWriter := csv.NewWriter(writer)
+	records := [][]string{{"key", "name"}}
+	for _, result := range results {
+		records = append(records, []string{result.Key, result.Name})
+	}
+
+	return csvWriter.WriteAll(records)
+}
+
+// writeResultsDotenv writes the names as environment variables named after
+// the keys. E.g.: ORDERS_BUCKET=my-prod-stack-orders-bucket.
+func writeResultsDotenv(writer io.Writer, results []result, _ string) error {
+	var errs []error
+	keys := make(map[string]string, len(results))
+	lines := make([]string, 0, len(results))
+	for _, result := range results {
+		variable := environmentVariable(result.Key)
+		if key, ok := keys[variable]; ok {
+			errs = append(errs, fmt.Errorf("keys %q and %q are both written as variable %s", key, result.Key, variable))
+
+			continue
+		}
+		keys[variable] = result.Key
+		lines = append(lines, variable+"="+result.Name+"\n")
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	_, err := io.WriteString(writer, strings.Join(lines, ""))
+
+	return err
+}
+
+// writeResultsTfvars writes the names as a Terraform tfvars JSON map of keys
+// to names held by the variable.
+func writeResultsTfvars(writer io.Writer, results []result, variable string) error {
+	names := make(map[string]string, len(results))
+	for _, result := range results {
+		names[result.Key] = result.Name
+	}
+
+	return writeJSON(writer, map[string]map[string]string{variable: names})
+}
+
+// environmentVariable converts a key into an environment variable name.
+func environmentVariable(key string) string {
+	variable := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'):
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+
+	if variable != "" && variable[0] >= '0' && variable[0] <= '9' {
+		return "_" + variable
+	}
+
+	return variable
+}
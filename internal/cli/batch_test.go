@@ -0,0 +1,192 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/davidmontoyago/commodity-namer/internal/cli"
+)
+
+const yamlSpecs = `
+- name: orders
+  type: bucket
+- key: processor
+  name: backend_processor
+  type: service.account
+  maxLength: 30
+`
+
+func TestRun_Batch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		args           []string
+		stdin          string
+		expectedStdout string
+	}{
+		{
+			name:  "yaml to json",
+			args:  []string{"batch", "-base", "my-prod-stack", "-replace"},
+			stdin: yamlSpecs,
+			expectedStdout: `[
+  {
+    "key": "orders-bucket",
+    "name": "my-prod-stack-orders-bucket",
+    "resourceName": "orders",
+    "resourceType": "bucket",
+    "maxLength": 63
+  },
+  {
+    "key": "processor",
+    "name": "my-prod-backend-pr-service-a",
+    "resourceName": "backend_processor",
+    "resourceType": "service.account",
+    "maxLength": 30
+  }
+]
+`,
+		},
+		{
+			name:           "yaml to dotenv",
+			args:           []string{"batch", "-base", "my-prod-stack", "-replace", "-format", "dotenv"},
+			stdin:          yamlSpecs,
+			expectedStdout: "ORDERS_BUCKET=my-prod-stack-orders-bucket\nPROCESSOR=my-prod-backend-pr-service-a\n",
+		},
+		{
+			name:  "json to tfvars",
+			args:  []string{"batch", "-base", "my-prod-stack", "-specs-format", "json", "-format", "tfvars", "-tfvars-variable", "bucket_names"},
+			stdin: `[{"name": "orders", "type": "bucket"}, {"key": "assets", "name": "assets", "type": "bucket"}]`,
+			expectedStdout: `{
+  "bucket_names": {
+    "assets": "my-prod-stack-assets-bucket",
+    "orders-bucket": "my-prod-stack-orders-bucket"
+  }
+}
+`,
+		},
+		{
+			name:           "csv to csv",
+			args:           []string{"batch", "-base", "my-prod-stack", "-specs-format", "csv", "-format", "csv"},
+			stdin:          "type,name,maxLength\nbucket,orders,\npod,worker,12\n",
+			expectedStdout: "key,name\norders-bucket,my-prod-stack-orders-bucket\nworker-pod,m-worker-pod\n",
+		},
+		{
+			name:           "no specs",
+			args:           []string{"batch", "-base", "my-prod-stack"},
+			stdin:          "",
+			expectedStdout: "[]\n",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+			code := cli.Run(testCase.args, strings.NewReader(testCase.stdin), &stdout, &stderr)
+
+			if code != cli.ExitOK {
+				t.Fatalf("Run() = %d, want %d, stderr: %s", code, cli.ExitOK, stderr.String())
+			}
+
+			if stdout.String() != testCase.expectedStdout {
+				t.Errorf("Run() stdout = %s, want %s", stdout.String(), testCase.expectedStdout)
+			}
+		})
+	}
+}
+
+func TestRun_BatchSpecsFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "specs.csv")
+	if err := os.WriteFile(path, []byte("name,type\norders,bucket\n"), 0o600); err != nil {
+		t.Fatalf("failed to write specs: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := cli.Run([]string{"batch", "-base", "my-prod-stack", "-specs", path, "-format", "dotenv"}, strings.NewReader(""), &stdout, &stderr)
+
+	if code != cli.ExitOK || stdout.String() != "ORDERS_BUCKET=my-prod-stack-orders-bucket\n" {
+		t.Errorf("Run() = %d, stdout %q, stderr %q, want the names of the specs file", code, stdout.String(), stderr.String())
+	}
+}
+
+func TestRun_BatchErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		args           []string
+		stdin          string
+		expectedCode   int
+		expectedStderr []string
+	}{
+		{
+			name:         "every invalid spec is reported",
+			args:         []string{"batch", "-base", "my-prod-stack", "-specs-format", "csv"},
+			stdin:        "key,name,type,maxLength\n,worker-10,pod,12\n,worker-11,pod,12\nbad,Orders,bucket,abc\nbad,,bucket,\n",
+			expectedCode: cli.ExitInvalid,
+			expectedStderr: []string{
+				`commodity-namer: line 4: maxLength "abc" is not a number`,
+				"commodity-namer: spec 4: name is required",
+				`commodity-namer: spec 4: duplicate key "bad"`,
+				`commodity-namer: worker-10/pod (max 12) and worker-11/pod (max 12) both generate name "my-pr-wor-p"`,
+				`commodity-namer: Orders/bucket (max 63): name must only contain lowercase letters, digits and "-", found "O"`,
+			},
+		},
+		{
+			name:           "unknown field",
+			args:           []string{"batch"},
+			stdin:          "- name: orders\n  length: 30\n",
+			expectedCode:   cli.ExitInvalid,
+			expectedStderr: []string{"field length not found"},
+		},
+		{
+			name:           "dotenv variables colliding",
+			args:           []string{"batch", "-format", "dotenv"},
+			stdin:          "- {key: orders-bucket, name: orders}\n- {key: orders_bucket, name: assets}\n",
+			expectedCode:   cli.ExitInvalid,
+			expectedStderr: []string{`keys "orders-bucket" and "orders_bucket" are both written as variable ORDERS_BUCKET`},
+		},
+		{
+			name:           "unknown output format",
+			args:           []string{"batch", "-format", "xml"},
+			expectedCode:   cli.ExitUsage,
+			expectedStderr: []string{`unknown output format "xml"`},
+		},
+		{
+			name:           "missing specs file",
+			args:           []string{"batch", "-specs", "missing.yaml"},
+			expectedCode:   cli.ExitUsage,
+			expectedStderr: []string{"missing.yaml"},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+			code := cli.Run(testCase.args, strings.NewReader(testCase.stdin), &stdout, &stderr)
+
+			if code != testCase.expectedCode {
+				t.Errorf("Run() = %d, want %d, stderr: %s", code, testCase.expectedCode, stderr.String())
+			}
+
+			if stdout.Len() > 0 {
+				t.Errorf("Run() stdout = %q, want no output", stdout.String())
+			}
+
+			for _, expected := range testCase.expectedStderr {
+				if !strings.Contains(stderr.String(), expected) {
+					t.Errorf("Run() stderr = %s, want it to contain %q", stderr.String(), expected)
+				}
+			}
+		})
+	}
+}
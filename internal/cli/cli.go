@@ -0,0 +1,138 @@
+// Package cli implements the commodity-namer command.
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+)
+
+// Exit codes.
+const (
+	// ExitOK means every name is valid.
+	ExitOK = 0
+	// ExitInvalid means a name is not valid or the command failed.
+	ExitInvalid = 1
+	// ExitUsage means the arguments are not valid.
+	ExitUsage = 2
+)
+
+// program is the command name in messages.
+const program = "commodity-namer"
+
+// Run runs the command with the arguments, without the program name, and
+// returns the exit code. Errors are written to stderr instead of panicking.
+//
+// Without a subcommand it generates a single name. Subcommands:
+//   - batch: generates the names of every spec of a YAML, JSON or CSV file
+//   - validate: checks existing names against the rules of a profile
+//   - serve: runs the HTTP naming service
+//   - terraform: speaks the protocol of the Terraform external data source
+func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) > 0 {
+		if command, ok := commands[args[0]]; ok {
+			return command(args[1:], stdin, stdout, stderr)
+		}
+	}
+
+	return generate(args, stdout, stderr)
+}
+
+// commands holds the subcommands by name.
+var commands = map[string]func(args []string, stdin io.Reader, stdout, stderr io.Writer) int{
+	"batch":     batch,
+	"validate":  validate,
+	"serve":     serve,
+	"terraform": terraform,
+}
+
+// namerFlags holds the flags that configure the Namer.
+type namerFlags struct {
+	base    string
+	replace bool
+	profile string
+}
+
+// register adds the flags to the flag set.
+func (f *namerFlags) register(flags *flag.FlagSet) {
+	flags.StringVar(&f.base, "base", "", "base name prefixed to every name")
+	flags.BoolVar(&f.replace, "replace", false, "replace periods, underscores and slashes and convert to lowercase")
+	flags.StringVar(&f.profile, "profile", "", "naming rules, one of "+strings.Join(namer.ProfileNames(), ", ")+" (default RFC 1035)")
+}
+
+// namer builds the Namer and returns the max length of its profile.
+func (f *namerFlags) namer() (namer.Namer, int, error) {
+	return namer.Config{Base: f.base, Replace: f.replace, Profile: f.profile}.Namer()
+}
+
+// generate prints the name of a resource, or its explanation as JSON.
+func generate(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet(program, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	var namerFlags namerFlags
+	namerFlags.register(flags)
+	name := flags.String("name", "", "resource name (required)")
+	resourceType := flags.String("type", "", "resource type or group")
+	maxLength := flags.Int("max-length", 0, "max length of the name (default the profile max length)")
+	asJSON := flags.Bool("json", false, "print the name with its derivation as JSON")
+
+	if err := flags.Parse(args); err != nil {
+		return ExitUsage
+	}
+
+	if *name == "" {
+		return usageError(stderr, flags, errors.New("-name is required"))
+	}
+
+	n, profileMaxLength, err := namerFlags.namer()
+	if err != nil {
+		return usageError(stderr, flags, err)
+	}
+
+	if *maxLength <= 0 {
+		*maxLength = profileMaxLength
+	}
+
+	explanation := n.Explain(*name, *resourceType, *maxLength)
+	if *asJSON {
+		if err := writeJSON(stdout, explanation); err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", program, err)
+
+			return ExitInvalid
+		}
+	}
+
+	if !explanation.Valid {
+		fmt.Fprintf(stderr, "%s: %q is not a valid name: %s\n", program, explanation.Name, explanation.Error)
+
+		return ExitInvalid
+	}
+
+	if !*asJSON {
+		fmt.Fprintln(stdout, explanation.Name)
+	}
+
+	return ExitOK
+}
+
+// usageError reports the error along with the usage.
+func usageError(stderr io.Writer, flags *flag.FlagSet, err error) int {
+	fmt.Fprintf(stderr, "%s: %v\n", program, err)
+	flags.Usage()
+
+	return ExitUsage
+}
+
+// writeJSON writes the value as indented JSON.
+func writeJSON(writer io.Writer, value any) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(value)
+}
@@ -0,0 +1,117 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+	"github.com/davidmontoyago/commodity-namer/internal/cli"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		args           []string
+		expectedCode   int
+		expectedStdout string
+		expectedStderr string
+	}{
+		{
+			name:           "name printed",
+			args:           []string{"-base", "my-prod-stack", "-name", "orders", "-type", "bucket"},
+			expectedCode:   cli.ExitOK,
+			expectedStdout: "my-prod-stack-orders-bucket\n",
+		},
+		{
+			name:           "truncated with replacements",
+			args:           []string{"-base", "my-prod-stack", "-name", "backend_processor", "-type", "service.account", "-max-length", "30", "-replace"},
+			expectedCode:   cli.ExitOK,
+			expectedStdout: "my-prod-backend-pr-service-a\n",
+		},
+		{
+			name:           "max length of the profile",
+			args:           []string{"-base", "enterprise", "-name", "backup", "-type", "storage-account", "-profile", "azure-storage", "-replace"},
+			expectedCode:   cli.ExitOK,
+			expectedStdout: "entebackupstorageaccount\n",
+		},
+		{
+			name:           "invalid name",
+			args:           []string{"-base", "My", "-name", "orders"},
+			expectedCode:   cli.ExitInvalid,
+			expectedStderr: `commodity-namer: "My-orders" is not a valid name: name must start with a lowercase letter`,
+		},
+		{
+			name:           "missing name",
+			args:           []string{"-base", "my-prod-stack"},
+			expectedCode:   cli.ExitUsage,
+			expectedStderr: "commodity-namer: -name is required",
+		},
+		{
+			name:           "unknown profile",
+			args:           []string{"-name", "orders", "-profile", "gcs"},
+			expectedCode:   cli.ExitUsage,
+			expectedStderr: `commodity-namer: unknown profile "gcs"`,
+		},
+		{
+			name:           "unknown flag",
+			args:           []string{"-name", "orders", "-length", "30"},
+			expectedCode:   cli.ExitUsage,
+			expectedStderr: "flag provided but not defined: -length",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+			code := cli.Run(testCase.args, strings.NewReader(""), &stdout, &stderr)
+
+			if code != testCase.expectedCode {
+				t.Errorf("Run() = %d, want %d, stderr: %s", code, testCase.expectedCode, stderr.String())
+			}
+
+			if stdout.String() != testCase.expectedStdout {
+				t.Errorf("Run() stdout = %q, want %q", stdout.String(), testCase.expectedStdout)
+			}
+
+			if !strings.Contains(stderr.String(), testCase.expectedStderr) {
+				t.Errorf("Run() stderr = %q, want it to contain %q", stderr.String(), testCase.expectedStderr)
+			}
+		})
+	}
+}
+
+func TestRun_JSON(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	code := cli.Run([]string{"-base", "fullstack-app", "-name", "frontend", "-type", "secret-access", "-max-length", "19", "-json"}, strings.NewReader(""), &stdout, &stderr)
+	if code != cli.ExitOK {
+		t.Fatalf("Run() = %d, want %d, stderr: %s", code, cli.ExitOK, stderr.String())
+	}
+
+	var explanation namer.Explanation
+	if err := json.Unmarshal(stdout.Bytes(), &explanation); err != nil {
+		t.Fatalf("failed to decode %q: %v", stdout.String(), err)
+	}
+
+	if explanation.Name != "fullst-fron-secret" || explanation.Truncation != namer.TruncationProportional || !explanation.Valid {
+		t.Errorf("Run() = %+v, want the valid name fullst-fron-secret truncated proportionally", explanation)
+	}
+}
+
+func TestRun_ServeInvalidAddress(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	code := cli.Run([]string{"serve", "-addr", "localhost:99999"}, strings.NewReader(""), &stdout, &stderr)
+
+	if code != cli.ExitInvalid || !strings.Contains(stderr.String(), "invalid port") {
+		t.Errorf("Run() = %d, stderr %q, want %d and the listen error", code, stderr.String(), cli.ExitInvalid)
+	}
+}
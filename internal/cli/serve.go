@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/davidmontoyago/commodity-namer/internal/server"
+)
+
+// readHeaderTimeout bounds the time to read request headers.
+const readHeaderTimeout = 10 * time.Second
+
+// serve runs the HTTP naming service until it fails.
+func serve(args []string, _ io.Reader, _, stderr io.Writer) int {
+	flags := flag.NewFlagSet(program+" serve", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	addr := flags.String("addr", "localhost:8080", "address to listen on")
+
+	if err := flags.Parse(args); err != nil {
+		return ExitUsage
+	}
+
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           server.NewHandler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	fmt.Fprintf(stderr, "%s: listening on %s\n", program, *addr)
+	if err := httpServer.ListenAndServe(); err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", program, err)
+
+		return ExitInvalid
+	}
+
+	return ExitOK
+}
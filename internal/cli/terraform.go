@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+)
+
+// terraformQueryKeys are the keys accepted in the query of the external data
+// source.
+var terraformQueryKeys = []string{"base", "name", "type", "max_length", "replace", "profile"}
+
+// terraform speaks the protocol of the Terraform external data source: it
+// reads a flat JSON object of strings from stdin and writes a flat JSON object
+// of strings to stdout with the name and its untruncated components. Errors
+// are written to stderr with a non-zero exit code, as Terraform expects.
+// See: https://registry.terraform.io/providers/hashicorp/external/latest/docs/data-sources/external
+func terraform(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet(program+" terraform", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	if err := flags.Parse(args); err != nil {
+		return ExitUsage
+	}
+
+	var query map[string]string
+	if err := json.NewDecoder(stdin).Decode(&query); err != nil {
+		fmt.Fprintf(stderr, "%s: failed to decode query: %v\n", program, err)
+
+		return ExitUsage
+	}
+
+	result, err := terraformResult(query)
+	if err != nil {
+		return reportErrors(stderr, err)
+	}
+
+	if err := json.NewEncoder(stdout).Encode(result); err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", program, err)
+
+		return ExitInvalid
+	}
+
+	return ExitOK
+}
+
+// terraformResult generates the name of the query. Every invalid key is
+// reported at once.
+func terraformResult(query map[string]string) (map[string]string, error) {
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(query)) {
+		if !slices.Contains(terraformQueryKeys, key) {
+			errs = append(errs, fmt.Errorf("unknown query key %q, expected one of %s", key, strings.Join(terraformQueryKeys, ", ")))
+		}
+	}
+
+	if query["name"] == "" {
+		errs = append(errs, errors.New("query key \"name\" is required"))
+	}
+
+	namerFlags := namerFlags{base: query["base"], profile: query["profile"]}
+	if value, ok := query["replace"]; ok {
+		replace, err := strconv.ParseBool(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("replace %q is not a boolean", value))
+		}
+		namerFlags.replace = replace
+	}
+
+	maxLength := 0
+	if value, ok := query["max_length"]; ok {
+		var err error
+		if maxLength, err = strconv.Atoi(value); err != nil {
+			errs = append(errs, fmt.Errorf("max_length %q is not a number", value))
+		}
+	}
+
+	n, profileMaxLength, err := namerFlags.namer()
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	if maxLength <= 0 {
+		maxLength = profileMaxLength
+	}
+
+	explanation := n.Explain(query["name"], query["type"], maxLength)
+	if !explanation.Valid {
+		return nil, fmt.Errorf("%q is not a valid name: %s", explanation.Name, explanation.Error)
+	}
+
+	// every key is always set, so modules can reference them
+	result := map[string]string{
+		"name":          explanation.Name,
+		"base":          "",
+		"resource_name": "",
+		"resource_type": "",
+		"truncated":     strconv.FormatBool(explanation.Surplus > 0),
+	}
+	for _, component := range explanation.Components {
+		switch component.Segment {
+		case namer.SegmentBase:
+			result["base"] = component.Value
+		case namer.SegmentName:
+			result["resource_name"] = component.Value
+		case namer.SegmentType:
+			result["resource_type"] = component.Value
+		}
+	}
+
+	return result, nil
+}
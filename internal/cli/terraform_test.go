@@ -0,0 +1,123 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/davidmontoyago/commodity-namer/internal/cli"
+)
+
+func TestRun_Terraform(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		query    string
+		expected map[string]string
+	}{
+		{
+			name:  "name with untruncated components",
+			query: `{"base": "my-prod-stack", "name": "backend_processor", "type": "service.account", "max_length": "30", "replace": "true"}`,
+			expected: map[string]string{
+				"name":          "my-prod-backend-pr-service-a",
+				"base":          "my-prod-stack",
+				"resource_name": "backend-processor",
+				"resource_type": "service-account",
+				"truncated":     "true",
+			},
+		},
+		{
+			name:  "max length of the profile",
+			query: `{"base": "enterprise", "name": "backup", "profile": "azure-storage"}`,
+			expected: map[string]string{
+				"name":          "enterprisebackup",
+				"base":          "enterprise",
+				"resource_name": "backup",
+				"resource_type": "",
+				"truncated":     "false",
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+			code := cli.Run([]string{"terraform"}, strings.NewReader(testCase.query), &stdout, &stderr)
+			if code != cli.ExitOK {
+				t.Fatalf("Run() = %d, want %d, stderr: %s", code, cli.ExitOK, stderr.String())
+			}
+
+			var result map[string]string
+			if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+				t.Fatalf("failed to decode %q: %v", stdout.String(), err)
+			}
+
+			if !maps.Equal(result, testCase.expected) {
+				t.Errorf("Run() = %v, want %v", result, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestRun_TerraformErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedCode   int
+		expectedStderr []string
+	}{
+		{
+			name:         "every invalid key is reported",
+			query:        `{"base": "my-prod-stack", "length": "30", "max_length": "thirty", "replace": "maybe"}`,
+			expectedCode: cli.ExitInvalid,
+			expectedStderr: []string{
+				`commodity-namer: unknown query key "length"`,
+				`commodity-namer: query key "name" is required`,
+				`commodity-namer: replace "maybe" is not a boolean`,
+				`commodity-namer: max_length "thirty" is not a number`,
+			},
+		},
+		{
+			name:           "invalid name",
+			query:          `{"base": "My", "name": "orders"}`,
+			expectedCode:   cli.ExitInvalid,
+			expectedStderr: []string{`commodity-namer: "My-orders" is not a valid name: name must start with a lowercase letter`},
+		},
+		{
+			name:           "values that are not strings",
+			query:          `{"name": "orders", "max_length": 30}`,
+			expectedCode:   cli.ExitUsage,
+			expectedStderr: []string{"failed to decode query"},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+			code := cli.Run([]string{"terraform"}, strings.NewReader(testCase.query), &stdout, &stderr)
+
+			if code != testCase.expectedCode {
+				t.Errorf("Run() = %d, want %d, stderr: %s", code, testCase.expectedCode, stderr.String())
+			}
+
+			if stdout.Len() > 0 {
+				t.Errorf("Run() stdout = %q, want no output", stdout.String())
+			}
+
+			for _, expected := range testCase.expectedStderr {
+				if !strings.Contains(stderr.String(), expected) {
+					t.Errorf("Run() stderr = %s, want it to contain %q", stderr.String(), expected)
+				}
+			}
+		})
+	}
+}
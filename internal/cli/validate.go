@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+)
+
+// validate checks existing names against the rules of a profile. Names are
+// read from the arguments, or one per line from a file or stdin. Every
+// violation is reported with the line of the name.
+func validate(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet(program+" validate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	profileName := flags.String("profile", namer.ProfileGCP, "naming rules, one of "+strings.Join(namer.ProfileNames(), ", "))
+	path := flags.String("file", "-", "file with one name per line, or - for stdin, when no names are given as arguments")
+
+	if err := flags.Parse(args); err != nil {
+		return ExitUsage
+	}
+
+	profile, err := namer.LookupProfile(*profileName)
+	if err != nil {
+		return usageError(stderr, flags, err)
+	}
+
+	if flags.NArg() > 0 {
+		return reportViolations(stdout, profile, "argument", flags.Args())
+	}
+
+	input, err := openInput(*path, stdin)
+	if err != nil {
+		return usageError(stderr, flags, err)
+	}
+	defer input.Close()
+
+	source := *path
+	if source == "-" {
+		source = "stdin"
+	}
+
+	// blank lines and comments are kept to preserve line numbers
+	var lines []string
+	scanner := bufio.NewScanner(input)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(stderr, "%s: failed to read %s: %v\n", program, source, err)
+
+		return ExitUsage
+	}
+
+	return reportViolations(stdout, profile, source, lines)
+}
+
+// reportViolations writes every violation of the names as
+// "<source>:<line>: <name>: <violation>". Blank lines and lines starting with
+// "#" are skipped.
+func reportViolations(stdout io.Writer, profile namer.Profile, source string, names []string) int {
+	code := ExitOK
+	for i, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || strings.HasPrefix(name, "#") {
+			continue
+		}
+
+		err := profile.Validate(name)
+		if err == nil {
+			continue
+		}
+		code = ExitInvalid
+
+		for violation := range strings.SplitSeq(err.Error(), "\n") {
+			fmt.Fprintf(stdout, "%s:%d: %s: %s\n", source, i+1, name, violation)
+		}
+	}
+
+	return code
+}
@@ -0,0 +1,84 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/davidmontoyago/commodity-namer/internal/cli"
+)
+
+func TestRun_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		args           []string
+		stdin          string
+		expectedCode   int
+		expectedStdout string
+	}{
+		{
+			name:         "valid names from arguments",
+			args:         []string{"validate", "my-prod-stack-orders-bucket", "assets-cache"},
+			expectedCode: cli.ExitOK,
+		},
+		{
+			name:         "every violation is reported",
+			args:         []string{"validate", "-profile", "aws-s3", "assets.example.com", "ab", "My_Bucket-"},
+			expectedCode: cli.ExitInvalid,
+			expectedStdout: "argument:2: ab: name must be at least 3 characters\n" +
+				"argument:3: My_Bucket-: name must start with a lowercase letter or digit\n" +
+				"argument:3: My_Bucket-: name must end with a lowercase letter or digit\n" +
+				`argument:3: My_Bucket-: name must only contain lowercase letters, digits and "-.", found "_B"` + "\n",
+		},
+		{
+			name:           "names from stdin with line numbers",
+			args:           []string{"validate", "-profile", "kubernetes"},
+			stdin:          "# workers\n1st-worker\n\n  orders_api  \n",
+			expectedCode:   cli.ExitInvalid,
+			expectedStdout: `stdin:4: orders_api: name must only contain lowercase letters, digits and "-", found "_"` + "\n",
+		},
+		{
+			name:         "unknown profile",
+			args:         []string{"validate", "-profile", "gcs", "orders"},
+			expectedCode: cli.ExitUsage,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+			code := cli.Run(testCase.args, strings.NewReader(testCase.stdin), &stdout, &stderr)
+
+			if code != testCase.expectedCode {
+				t.Errorf("Run() = %d, want %d, stderr: %s", code, testCase.expectedCode, stderr.String())
+			}
+
+			if stdout.String() != testCase.expectedStdout {
+				t.Errorf("Run() stdout = %s, want %s", stdout.String(), testCase.expectedStdout)
+			}
+		})
+	}
+}
+
+func TestRun_ValidateFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "names.txt")
+	if err := os.WriteFile(path, []byte("enterprisebackup\nenterprise-backup\n"), 0o600); err != nil {
+		t.Fatalf("failed to write names: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := cli.Run([]string{"validate", "-profile", "azure-storage", "-file", path}, strings.NewReader(""), &stdout, &stderr)
+
+	expected := path + `:2: enterprise-backup: name must only contain lowercase letters and digits, found "-"` + "\n"
+	if code != cli.ExitInvalid || stdout.String() != expected {
+		t.Errorf("Run() = %d, stdout %q, want %d, %q", code, stdout.String(), cli.ExitInvalid, expected)
+	}
+}
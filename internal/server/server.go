@@ -0,0 +1,263 @@
+// Package server implements a local HTTP naming service, so non-Go tooling can
+// call the canonical implementation. Every endpoint takes and returns JSON,
+// and errors are returned as structured JSON instead of panicking.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+)
+
+// maxRequestSize is the max size of request bodies.
+const maxRequestSize = 1 << 20
+
+// NamerConfig configures the Namer of a request.
+type NamerConfig struct {
+	// Base is the base name prefixed to every name.
+	Base string `json:"base"`
+	// Replace replaces periods, underscores and slashes and converts to lowercase.
+	Replace bool `json:"replace"`
+	// Profile sets the naming rules. E.g.: aws-s3. Defaults to RFC 1035.
+	Profile string `json:"profile"`
+}
+
+// GenerateRequest is the body of /generate and /explain.
+type GenerateRequest struct {
+	NamerConfig
+
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// MaxLength defaults to the profile max length.
+	MaxLength int `json:"maxLength"`
+}
+
+// GenerateResponse is the body returned by /generate.
+type GenerateResponse struct {
+	Name string `json:"name"`
+}
+
+// ValidateRequest is the body of /validate.
+type ValidateRequest struct {
+	Names []string `json:"names"`
+	// Profile sets the naming rules. Defaults to gcp.
+	Profile string `json:"profile"`
+}
+
+// ValidateResponse is the body returned by /validate.
+type ValidateResponse struct {
+	Valid   bool             `json:"valid"`
+	Results []ValidateResult `json:"results"`
+}
+
+// ValidateResult is the validation result of a name.
+type ValidateResult struct {
+	Name       string   `json:"name"`
+	Valid      bool     `json:"valid"`
+	Violations []string `json:"violations,omitempty"`
+}
+
+// BatchRequest is the body of /batch.
+type BatchRequest struct {
+	NamerConfig
+
+	Specs []BatchSpec `json:"specs"`
+}
+
+// BatchSpec is a resource spec of a batch. MaxLength defaults to the profile
+// max length.
+type BatchSpec struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	MaxLength int    `json:"maxLength"`
+}
+
+// BatchResponse is the body returned by /batch, with the names in the order
+// of the specs.
+type BatchResponse struct {
+	Names []string `json:"names"`
+}
+
+// ErrorResponse is the body returned on errors.
+type ErrorResponse struct {
+	Error string `json:"error"`
+	// Details lists every validation error.
+	Details []string `json:"details,omitempty"`
+}
+
+// NewHandler returns the handler of the naming service, with the endpoints:
+//   - POST /generate: generates a name
+//   - POST /explain: traces how a name is derived
+//   - POST /validate: checks names against the rules of a profile
+//   - POST /batch: generates many names at once
+func NewHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /generate", handleGenerate)
+	mux.HandleFunc("POST /explain", handleExplain)
+	mux.HandleFunc("POST /validate", handleValidate)
+	mux.HandleFunc("POST /batch", handleBatch)
+
+	return mux
+}
+
+// handleGenerate generates a name, or returns its validation errors.
+func handleGenerate(writer http.ResponseWriter, request *http.Request) {
+	explanation, ok := explain(writer, request)
+	if !ok {
+		return
+	}
+
+	if !explanation.Valid {
+		writeError(writer, http.StatusUnprocessableEntity, fmt.Errorf("%q is not a valid name", explanation.Name),
+			errors.New(explanation.Error))
+
+		return
+	}
+
+	writeJSON(writer, http.StatusOK, GenerateResponse{Name: explanation.Name})
+}
+
+// handleExplain traces how a name is derived. Names that are not valid are
+// explained as well.
+func handleExplain(writer http.ResponseWriter, request *http.Request) {
+	explanation, ok := explain(writer, request)
+	if !ok {
+		return
+	}
+
+	writeJSON(writer, http.StatusOK, explanation)
+}
+
+// explain decodes the request and explains the name. It writes the error
+// response and returns false when the request is not valid.
+func explain(writer http.ResponseWriter, request *http.Request) (namer.Explanation, bool) {
+	var body GenerateRequest
+	if !decode(writer, request, &body) {
+		return namer.Explanation{}, false
+	}
+
+	if body.Name == "" {
+		writeError(writer, http.StatusBadRequest, errors.New("name is required"))
+
+		return namer.Explanation{}, false
+	}
+
+	n, profileMaxLength, err := body.namer()
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+
+		return namer.Explanation{}, false
+	}
+
+	maxLength := body.MaxLength
+	if maxLength <= 0 {
+		maxLength = profileMaxLength
+	}
+
+	return n.Explain(body.Name, body.Type, maxLength), true
+}
+
+// handleValidate checks every name against the rules of the profile.
+func handleValidate(writer http.ResponseWriter, request *http.Request) {
+	var body ValidateRequest
+	if !decode(writer, request, &body) {
+		return
+	}
+
+	if body.Profile == "" {
+		body.Profile = namer.ProfileGCP
+	}
+
+	profile, err := namer.LookupProfile(body.Profile)
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+
+		return
+	}
+
+	response := ValidateResponse{Valid: true, Results: make([]ValidateResult, len(body.Names))}
+	for i, name := range body.Names {
+		response.Results[i] = ValidateResult{Name: name, Valid: true}
+		if err := profile.Validate(name); err != nil {
+			response.Valid = false
+			response.Results[i].Valid = false
+			response.Results[i].Violations = strings.Split(err.Error(), "\n")
+		}
+	}
+
+	writeJSON(writer, http.StatusOK, response)
+}
+
+// handleBatch generates the names of every spec, or returns every error.
+func handleBatch(writer http.ResponseWriter, request *http.Request) {
+	var body BatchRequest
+	if !decode(writer, request, &body) {
+		return
+	}
+
+	n, profileMaxLength, err := body.namer()
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+
+		return
+	}
+
+	specs := make([]namer.ResourceSpec, len(body.Specs))
+	for i, spec := range body.Specs {
+		specs[i] = namer.ResourceSpec{Name: spec.Name, Type: spec.Type, MaxLength: spec.MaxLength}
+		if spec.MaxLength <= 0 {
+			specs[i].MaxLength = profileMaxLength
+		}
+	}
+
+	names, err := n.NewResourceNameBatch(specs)
+	if err != nil {
+		writeError(writer, http.StatusUnprocessableEntity, errors.New("specs are not valid"), err)
+
+		return
+	}
+
+	writeJSON(writer, http.StatusOK, BatchResponse{Names: names})
+}
+
+// namer builds the Namer and returns the max length of its profile, as the
+// CLI does.
+func (c NamerConfig) namer() (namer.Namer, int, error) {
+	return namer.Config{Base: c.Base, Replace: c.Replace, Profile: c.Profile}.Namer()
+}
+
+// decode decodes the JSON request body. Unknown fields are rejected. It writes
+// the error response and returns false when the body can't be decoded.
+func decode(writer http.ResponseWriter, request *http.Request, body any) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(writer, request.Body, maxRequestSize))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(body); err != nil {
+		writeError(writer, http.StatusBadRequest, fmt.Errorf("failed to decode request: %w", err))
+
+		return false
+	}
+
+	return true
+}
+
+// writeError writes the error, with the lines of the details error as details.
+func writeError(writer http.ResponseWriter, status int, err error, details ...error) {
+	response := ErrorResponse{Error: err.Error()}
+	for _, detail := range details {
+		response.Details = append(response.Details, strings.Split(detail.Error(), "\n")...)
+	}
+
+	writeJSON(writer, status, response)
+}
+
+// writeJSON writes the value as JSON with the status.
+func writeJSON(writer http.ResponseWriter, status int, value any) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	_ = json.NewEncoder(writer).Encode(value)
+}
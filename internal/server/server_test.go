@@ -0,0 +1,143 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+	"github.com/davidmontoyago/commodity-namer/internal/server"
+)
+
+func TestNewHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "generate",
+			path:           "/generate",
+			body:           `{"base": "my-prod-stack", "name": "backend_processor", "type": "service.account", "maxLength": 30, "replace": true}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":"my-prod-backend-pr-service-a"}`,
+		},
+		{
+			name:           "generate with the profile max length",
+			path:           "/generate",
+			body:           `{"base": "enterprise", "name": "backup", "type": "storage-account", "profile": "azure-storage", "replace": true}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":"entebackupstorageaccount"}`,
+		},
+		{
+			name:           "generate an invalid name",
+			path:           "/generate",
+			body:           `{"base": "My", "name": "orders"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"error":"\"My-orders\" is not a valid name","details":["name must start with a lowercase letter"]}`,
+		},
+		{
+			name:           "generate without a name",
+			path:           "/generate",
+			body:           `{"base": "my-prod-stack"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"name is required"}`,
+		},
+		{
+			name:           "unknown profile",
+			path:           "/generate",
+			body:           `{"name": "orders", "profile": "gcs"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"unknown profile \"gcs\", expected one of aws-s3, azure-storage, gcp, kubernetes"}`,
+		},
+		{
+			name:           "unknown field",
+			path:           "/generate",
+			body:           `{"name": "orders", "length": 30}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"failed to decode request: json: unknown field \"length\""}`,
+		},
+		{
+			name:           "validate",
+			path:           "/validate",
+			body:           `{"names": ["assets.example.com", "ab"], "profile": "aws-s3"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"valid":false,"results":[{"name":"assets.example.com","valid":true},` +
+				`{"name":"ab","valid":false,"violations":["name must be at least 3 characters"]}]}`,
+		},
+		{
+			name:           "batch",
+			path:           "/batch",
+			body:           `{"base": "my-prod-stack", "specs": [{"name": "orders", "type": "bucket"}, {"name": "worker", "type": "pod", "maxLength": 12}]}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"names":["my-prod-stack-orders-bucket","m-worker-pod"]}`,
+		},
+		{
+			name:           "batch with collisions",
+			path:           "/batch",
+			body:           `{"base": "my-prod-stack", "specs": [{"name": "worker-10", "type": "pod", "maxLength": 12}, {"name": "worker-11", "type": "pod", "maxLength": 12}]}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody: `{"error":"specs are not valid","details":[` +
+				`"worker-10/pod (max 12) and worker-11/pod (max 12) both generate name \"my-pr-wor-p\""]}`,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodGet,
+			path:           "/generate",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			method := testCase.method
+			if method == "" {
+				method = http.MethodPost
+			}
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(method, testCase.path, strings.NewReader(testCase.body))
+			server.NewHandler().ServeHTTP(recorder, request)
+
+			if recorder.Code != testCase.expectedStatus {
+				t.Errorf("status = %d, want %d, body: %s", recorder.Code, testCase.expectedStatus, recorder.Body.String())
+			}
+
+			if testCase.expectedBody != "" && strings.TrimSpace(recorder.Body.String()) != testCase.expectedBody {
+				t.Errorf("body = %s, want %s", recorder.Body.String(), testCase.expectedBody)
+			}
+		})
+	}
+}
+
+func TestNewHandler_Explain(t *testing.T) {
+	t.Parallel()
+
+	httpServer := httptest.NewServer(server.NewHandler())
+	defer httpServer.Close()
+
+	body := `{"base": "fullstack-app", "name": "frontend", "type": "secret-access", "maxLength": 19}`
+	response, err := http.Post(httpServer.URL+"/explain", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST /explain error = %v", err)
+	}
+	defer response.Body.Close()
+
+	var explanation namer.Explanation
+	if err := json.NewDecoder(response.Body).Decode(&explanation); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if explanation.Name != "fullst-fron-secret" || explanation.Truncation != namer.TruncationProportional || explanation.Factor != 0.52 {
+		t.Errorf("POST /explain = %+v, want fullst-fron-secret truncated proportionally by 0.52", explanation)
+	}
+}
@@ -0,0 +1,208 @@
+package namer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Kubernetes label limits.
+// See: https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#syntax-and-character-set
+const (
+	// MaxKubernetesNameLength is the max length of label values and of the name
+	// part of label and annotation keys.
+	MaxKubernetesNameLength = 63
+	// MaxKubernetesPrefixLength is the max length of the DNS subdomain prefix
+	// of label and annotation keys.
+	MaxKubernetesPrefixLength = 253
+)
+
+// maxDNSLabelLength is the max length of each DNS label of a prefix.
+const maxDNSLabelLength = 63
+
+// KubernetesLabels builds valid Kubernetes labels and annotations from
+// arbitrary input. Errors are collected and returned at once by Build.
+type KubernetesLabels struct {
+	prefix      string
+	labels      map[string]string
+	annotations map[string]string
+	errs        []error
+}
+
+// NewKubernetesLabels creates a builder. The prefix is added to keys without
+// one. E.g.: example.com. It may be empty.
+func NewKubernetesLabels(prefix string) *KubernetesLabels {
+	return &KubernetesLabels{
+		prefix:      prefix,
+		labels:      make(map[string]string),
+		annotations: make(map[string]string),
+	}
+}
+
+// Label adds a label with a valid key and value.
+func (k *KubernetesLabels) Label(key, value string) *KubernetesLabels {
+	validKey, err := k.key(key)
+	if err != nil {
+		k.errs = append(k.errs, err)
+
+		return k
+	}
+
+	if _, ok := k.labels[validKey]; ok {
+		k.errs = append(k.errs, fmt.Errorf("duplicate label %q", validKey))
+
+		return k
+	}
+	k.labels[validKey] = KubernetesLabelValue(value)
+
+	return k
+}
+
+// Annotation adds an annotation with a valid key. Annotation values are not
+// restricted and are kept as is.
+func (k *KubernetesLabels) Annotation(key, value string) *KubernetesLabels {
+	validKey, err := k.key(key)
+	if err != nil {
+		k.errs = append(k.errs, err)
+
+		return k
+	}
+
+	if _, ok := k.annotations[validKey]; ok {
+		k.errs = append(k.errs, fmt.Errorf("duplicate annotation %q", validKey))
+
+		return k
+	}
+	k.annotations[validKey] = value
+
+	return k
+}
+
+// Build returns the labels and annotations, or every error found.
+func (k *KubernetesLabels) Build() (labels map[string]string, annotations map[string]string, err error) {
+	if len(k.errs) > 0 {
+		return nil, nil, errors.Join(k.errs...)
+	}
+
+	return k.labels, k.annotations, nil
+}
+
+// key adds the builder prefix to keys without one.
+func (k *KubernetesLabels) key(key string) (string, error) {
+	if k.prefix != "" && !strings.Contains(key, "/") {
+		key = k.prefix + "/" + key
+	}
+
+	return KubernetesLabelKey(key)
+}
+
+// KubernetesLabelKey converts a key into a valid label or annotation key, with
+// an optional DNS subdomain prefix. E.g.: app.kubernetes.io/name. Characters
+// not allowed are replaced with hyphens, each DNS label of the prefix is
+// trimmed to alphanumeric ends, and parts over the length limit are truncated
+// with a hash suffix, so distinct keys stay distinct.
+func KubernetesLabelKey(key string) (string, error) {
+	prefix, name, hasPrefix := strings.Cut(key, "/")
+	if !hasPrefix {
+		prefix, name = "", key
+	}
+
+	name = kubernetesName(name, isKubernetesNameRune, MaxKubernetesNameLength)
+	if name == "" {
+		return "", fmt.Errorf("label key %q must have a name", key)
+	}
+
+	if !hasPrefix {
+		return name, nil
+	}
+
+	prefix = kubernetesPrefix(prefix)
+	if prefix == "" {
+		return "", fmt.Errorf("label key %q must have a prefix before '/'", key)
+	}
+
+	return prefix + "/" + name, nil
+}
+
+// KubernetesLabelValue converts a value into a valid label value. Characters
+// not allowed are replaced with hyphens and values over 63 characters are
+// truncated with a hash suffix. Values may be empty.
+func KubernetesLabelValue(value string) string {
+	return kubernetesName(value, isKubernetesNameRune, MaxKubernetesNameLength)
+}
+
+// kubernetesName replaces the characters not allowed, trims the ends to an
+// alphanumeric character and fits the result in the max length.
+func kubernetesName(value string, allowed func(rune) bool, maxLength int) string {
+	return fitWithHash(trimNonAlphanumeric(replaceNotAllowed(value, allowed)), maxLength, trimNonAlphanumeric)
+}
+
+// kubernetesPrefix converts a prefix into a valid DNS subdomain. Every DNS
+// label is normalized on its own, as each must start and end with an
+// alphanumeric character and fit in 63 characters. Empty labels are dropped.
+func kubernetesPrefix(prefix string) string {
+	prefix = fitWithHash(dnsLabels(prefix), MaxKubernetesPrefixLength, trimNonAlphanumeric)
+
+	// the hash suffix may push the last DNS label over its limit
+	return dnsLabels(prefix)
+}
+
+// dnsLabels normalizes every dot-separated DNS label of the value.
+func dnsLabels(value string) string {
+	var labels []string
+	for label := range strings.SplitSeq(strings.ToLower(value), ".") {
+		if label = kubernetesName(label, isKubernetesPrefixRune, maxDNSLabelLength); label != "" {
+			labels = append(labels, label)
+		}
+	}
+
+	return strings.Join(labels, ".")
+}
+
+// isKubernetesNameRune reports whether r is allowed in label names and values.
+func isKubernetesNameRune(r rune) bool {
+	return isAlphanumeric(r) || r == '-' || r == '_' || r == '.'
+}
+
+// isKubernetesPrefixRune reports whether r is allowed in a DNS label.
+func isKubernetesPrefixRune(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-'
+}
+
+// isAlphanumeric reports whether r is an ASCII letter or digit.
+func isAlphanumeric(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// replaceNotAllowed replaces the characters not allowed with hyphens.
+func replaceNotAllowed(value string, allowed func(rune) bool) string {
+	return strings.Map(func(r rune) rune {
+		if allowed(r) {
+			return r
+		}
+
+		return '-'
+	}, value)
+}
+
+// trimNonAlphanumeric trims characters other than letters and digits from
+// both ends, as names must start and end with an alphanumeric character.
+func trimNonAlphanumeric(value string) string {
+	return strings.TrimFunc(value, func(r rune) bool {
+		return !isAlphanumeric(r)
+	})
+}
+
+// fitWithHash truncates a value over the max length, replacing its end with a
+// hash of the full value. The cut is tidied with trim before adding the hash.
+func fitWithHash(value string, maxLength int, trim func(string) string) string {
+	if utf8.RuneCountInString(value) <= maxLength {
+		return value
+	}
+
+	hash := nameHash(value)
+	truncated := trim(truncateRunes(value, maxLength-len(hash)-1))
+
+	return truncated + "-" + hash
+}
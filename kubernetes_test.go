@@ -0,0 +1,219 @@
+package namer_test
+
+import (
+	"maps"
+	"regexp"
+	"strings"
+	"testing"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+)
+
+var kubernetesValuePattern = regexp.MustCompile(`^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?$`)
+
+var dnsLabelPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+func TestKubernetesLabelValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{
+			name:     "valid value unchanged",
+			value:    "my-prod-stack_orders.v1",
+			expected: "my-prod-stack_orders.v1",
+		},
+		{
+			name:     "case is kept",
+			value:    "Platform",
+			expected: "Platform",
+		},
+		{
+			name:     "characters not allowed replaced",
+			value:    "Platform Team/Infra",
+			expected: "Platform-Team-Infra",
+		},
+		{
+			name:     "must start and end with alphanumeric",
+			value:    "-_.orders.-_",
+			expected: "orders",
+		},
+		{
+			name:     "empty value",
+			value:    "",
+			expected: "",
+		},
+		{
+			name:     "long value truncated with hash",
+			value:    "a-very-long-label-value-that-goes-well-beyond-the-limit-of-sixty-three",
+			expected: "a-very-long-label-value-that-goes-well-beyond-the-limi-23ea9d6a",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := namer.KubernetesLabelValue(testCase.value)
+			if result != testCase.expected {
+				t.Errorf("KubernetesLabelValue() = %v, want %v", result, testCase.expected)
+			}
+
+			if len(result) > namer.MaxKubernetesNameLength || !kubernetesValuePattern.MatchString(result) {
+				t.Errorf("KubernetesLabelValue() = %v is not a valid label value", result)
+			}
+		})
+	}
+}
+
+func TestKubernetesLabelValue_DistinctAfterTruncation(t *testing.T) {
+	t.Parallel()
+
+	prefix := strings.Repeat("worker-pool-", 6)
+	first := namer.KubernetesLabelValue(prefix + "10")
+	second := namer.KubernetesLabelValue(prefix + "11")
+
+	if first == second {
+		t.Errorf("KubernetesLabelValue() = %v for both values, want distinct values", first)
+	}
+}
+
+func TestKubernetesLabelKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		key      string
+		expected string
+	}{
+		{
+			name:     "name only",
+			key:      "team",
+			expected: "team",
+		},
+		{
+			name:     "prefixed key",
+			key:      "app.kubernetes.io/name",
+			expected: "app.kubernetes.io/name",
+		},
+		{
+			name:     "prefix lowercased, name case kept",
+			key:      "App.Kubernetes.IO/Managed By",
+			expected: "app.kubernetes.io/Managed-By",
+		},
+		{
+			name:     "slashes in name replaced",
+			key:      "example.com/path/to/thing",
+			expected: "example.com/path-to-thing",
+		},
+		{
+			name:     "long prefix truncated with hash",
+			key:      strings.Repeat("sub.", 70) + "example.com/name",
+			expected: strings.Repeat("sub.", 60) + "sub-100f9fa6/name",
+		},
+		{
+			name:     "DNS labels trimmed to alphanumeric ends",
+			key:      "foo-.-bar_/x",
+			expected: "foo.bar/x",
+		},
+		{
+			name:     "empty DNS labels dropped",
+			key:      ".example..com./x",
+			expected: "example.com/x",
+		},
+		{
+			name:     "long DNS label truncated with hash",
+			key:      strings.Repeat("a", 70) + ".example.com/x",
+			expected: strings.Repeat("a", 54) + "-6bd5e503.example.com/x",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := namer.KubernetesLabelKey(testCase.key)
+			if err != nil {
+				t.Fatalf("KubernetesLabelKey() error = %v", err)
+			}
+
+			if result != testCase.expected {
+				t.Errorf("KubernetesLabelKey() = %v, want %v", result, testCase.expected)
+			}
+
+			prefix, name, hasPrefix := strings.Cut(result, "/")
+			if len(prefix) > namer.MaxKubernetesPrefixLength || len(name) > namer.MaxKubernetesNameLength {
+				t.Errorf("KubernetesLabelKey() = %v exceeds the key length limits", result)
+			}
+
+			for label := range strings.SplitSeq(prefix, ".") {
+				if hasPrefix && !dnsLabelPattern.MatchString(label) {
+					t.Errorf("KubernetesLabelKey() = %v has DNS label %q that is not valid", result, label)
+				}
+			}
+		})
+	}
+}
+
+func TestKubernetesLabelKey_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, key := range []string{"", "---", "example.com/", "/name"} {
+		t.Run(key, func(t *testing.T) {
+			t.Parallel()
+
+			if result, err := namer.KubernetesLabelKey(key); err == nil {
+				t.Errorf("KubernetesLabelKey(%q) = %v, want an error", key, result)
+			}
+		})
+	}
+}
+
+func TestKubernetesLabels_Build(t *testing.T) {
+	t.Parallel()
+
+	labels, annotations, err := namer.NewKubernetesLabels("example.com").
+		Label("team", "Platform Team").
+		Label("app.kubernetes.io/name", namer.New("my-prod-stack").NewResourceName("orders", "", 63)).
+		Annotation("description", "Orders API: v1").
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	expectedLabels := map[string]string{
+		"example.com/team":       "Platform-Team",
+		"app.kubernetes.io/name": "my-prod-stack-orders",
+	}
+	if !maps.Equal(labels, expectedLabels) {
+		t.Errorf("Build() labels = %v, want %v", labels, expectedLabels)
+	}
+
+	expectedAnnotations := map[string]string{"example.com/description": "Orders API: v1"}
+	if !maps.Equal(annotations, expectedAnnotations) {
+		t.Errorf("Build() annotations = %v, want %v", annotations, expectedAnnotations)
+	}
+}
+
+func TestKubernetesLabels_BuildErrors(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := namer.NewKubernetesLabels("").
+		Label("Team", "a").
+		Label("Team", "b").
+		Label("", "c").
+		Annotation("---", "d").
+		Build()
+	if err == nil {
+		t.Fatal("Build() expected an error")
+	}
+
+	for _, expected := range []string{`duplicate label "Team"`, `label key "" must have a name`, `label key "---" must have a name`} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Build() error = %v, want it to contain %q", err, expected)
+		}
+	}
+}
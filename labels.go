@@ -0,0 +1,180 @@
+package namer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// GCP label limits.
+// See: https://cloud.google.com/compute/docs/labeling-resources#requirements
+const (
+	// MaxLabelLength is the max length of label keys and values.
+	MaxLabelLength = 63
+	// MaxLabels is the max number of labels per resource.
+	MaxLabels = 64
+)
+
+// Keys of the companion labels that record the untruncated components of a name.
+const (
+	// LabelBase holds the full base name.
+	LabelBase = "namer-base"
+	// LabelName holds the full resource name.
+	LabelName = "namer-name"
+	// LabelType holds the full resource type.
+	LabelType = "namer-type"
+	// LabelHash holds a hash of the untruncated name.
+	LabelHash = "namer-hash"
+)
+
+// nameHashLength is the number of hex characters kept from the name hash.
+const nameHashLength = 8
+
+// NewResourceNameWithLabels generates a resource name like NewResourceName,
+// along with labels that record the untruncated base name, resource name and
+// type, and a hash of the untruncated name. Labels are valid GCP labels, which
+// are also valid AWS tags and Azure tags, so a truncated name can always be
+// traced back to its logical identity. Empty components are left out.
+func (e Namer) NewResourceNameWithLabels(resourceName, resourceType string, maxLength int) (string, map[string]string) {
+	name, segments, err := e.generate(maxLength, []Segment{
+		{Name: SegmentName, Value: resourceName},
+		{Name: SegmentType, Value: resourceType},
+	})
+	if err != nil {
+		e.log().Error("Not a valid resource name", "name", name, "error", err)
+		panic(panicValue(err))
+	}
+	e.notifyGenerated(name)
+
+	return name, e.companionLabels(segments)
+}
+
+// companionLabels records the untruncated segments as labels.
+func (e Namer) companionLabels(segments []Segment) map[string]string {
+	labels := map[string]string{
+		LabelHash: nameHash(e.join(segmentValues(segments)...)),
+	}
+
+	keys := map[string]string{
+		SegmentBase: LabelBase,
+		SegmentName: LabelName,
+		SegmentType: LabelType,
+	}
+	for _, segment := range segments {
+		if key, ok := keys[segment.Name]; ok && segment.Value != "" {
+			labels[key] = normalizeLabel(segment.Value)
+		}
+	}
+
+	return labels
+}
+
+// nameHash returns a short hex hash of the name.
+func nameHash(name string) string {
+	sum := sha256.Sum256([]byte(name))
+
+	return hex.EncodeToString(sum[:])[:nameHashLength]
+}
+
+// NewLabel normalizes a key and value into a valid GCP label. Characters are
+// converted to lowercase and those not allowed are replaced with hyphens.
+// Keys and values over 63 characters are truncated and end with a hash of the
+// full label, so distinct labels stay distinct. Keys must start with a
+// letter, so leading characters other than letters are trimmed, and can't be
+// empty.
+func NewLabel(key, value string) (string, string, error) {
+	if key == "" {
+		return "", "", errors.New("label key must not be empty")
+	}
+
+	rawKey := key
+	key = normalizeLabel(strings.TrimLeftFunc(key, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}))
+	if key == "" {
+		return "", "", fmt.Errorf("label key %q must contain a letter", rawKey)
+	}
+
+	if first := []rune(key)[0]; !unicode.IsLetter(first) {
+		return "", "", fmt.Errorf("label key %q must start with a lowercase letter", key)
+	}
+
+	return key, normalizeLabel(value), nil
+}
+
+// NewLabels normalizes a label set into valid GCP labels. The set is validated
+// as a whole: keys must be unique after normalization and there can't be more
+// than 64 labels. All errors are returned at once.
+func NewLabels(labels map[string]string) (map[string]string, error) {
+	return normalizeSet("label", labels, MaxLabels, NewLabel)
+}
+
+// normalizeSet normalizes every key and value of a set of labels or tags, as
+// named by kind. It reports sets over the limit, keys that collide after
+// normalization and every key that can't be normalized at once.
+func normalizeSet(kind string, set map[string]string, limit int,
+	normalize func(key, value string) (string, string, error),
+) (map[string]string, error) {
+	var errs []error
+	if len(set) > limit {
+		errs = append(errs, fmt.Errorf("%d %ss exceed the limit of %d", len(set), kind, limit))
+	}
+
+	normalized := make(map[string]string, len(set))
+	sources := make(map[string]string, len(set))
+	for _, rawKey := range sortedKeys(set) {
+		key, value, err := normalize(rawKey, set[rawKey])
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		if source, ok := sources[key]; ok {
+			errs = append(errs, fmt.Errorf("%s keys %q and %q are both normalized to %q", kind, source, rawKey, key))
+
+			continue
+		}
+		sources[key] = rawKey
+		normalized[key] = value
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return normalized, nil
+}
+
+// normalizeLabel converts a label key or value to lowercase letters, digits,
+// hyphens and underscores. Labels over the label length are truncated with a
+// hash of the full label, so distinct labels stay distinct. International
+// letters are allowed.
+func normalizeLabel(label string) string {
+	label = strings.Map(func(r rune) rune {
+		r = unicode.ToLower(r)
+		switch {
+		case unicode.IsLetter(r) && !unicode.IsUpper(r), unicode.IsDigit(r), r == '-', r == '_':
+			return r
+		default:
+			return '-'
+		}
+	}, label)
+
+	return fitWithHash(label, MaxLabelLength, func(label string) string {
+		return strings.TrimRight(label, "-_")
+	})
+}
+
+// truncateRunes truncates s to at most n characters.
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+
+	return string(runes[:n])
+}
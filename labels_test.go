@@ -0,0 +1,306 @@
+package namer_test
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+	"testing"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+)
+
+func TestNewResourceNameWithLabels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		namer          namer.Namer
+		serviceName    string
+		resourceType   string
+		maxLength      int
+		expectedName   string
+		expectedLabels map[string]string
+	}{
+		{
+			name:         "truncated name keeps full components",
+			namer:        namer.New("cloudflare-edge-waf"),
+			serviceName:  "l7-ruleset-ddos",
+			resourceType: "managed",
+			maxLength:    30,
+			expectedName: "cloudf-l7-ruleset-ddos-managed",
+			expectedLabels: map[string]string{
+				namer.LabelBase: "cloudflare-edge-waf",
+				namer.LabelName: "l7-ruleset-ddos",
+				namer.LabelType: "managed",
+			},
+		},
+		{
+			name:         "replacements apply to labels",
+			namer:        namer.New("app", namer.WithReplace()),
+			serviceName:  "User.Auth_Service",
+			resourceType: "",
+			maxLength:    63,
+			expectedName: "app-user-auth-service",
+			expectedLabels: map[string]string{
+				namer.LabelBase: "app",
+				namer.LabelName: "user-auth-service",
+			},
+		},
+		{
+			name:         "separators sanitized in labels",
+			namer:        namer.New("app", namer.WithSeparator(".")),
+			serviceName:  "orders",
+			resourceType: "bucket",
+			maxLength:    63,
+			expectedName: "app.orders.bucket",
+			expectedLabels: map[string]string{
+				namer.LabelBase: "app",
+				namer.LabelName: "orders",
+				namer.LabelType: "bucket",
+			},
+		},
+		{
+			name:         "long components truncated to label limit with a hash",
+			namer:        namer.New("app"),
+			serviceName:  "a-very-very-very-very-very-very-very-very-very-long-service-name",
+			resourceType: "bucket",
+			maxLength:    30,
+			expectedName: "a-a-very-very-very-very-ver-bu",
+			expectedLabels: map[string]string{
+				namer.LabelBase: "app",
+				namer.LabelName: "a-very-very-very-very-very-very-very-very-very-long-se-647b8d0a",
+				namer.LabelType: "bucket",
+			},
+		},
+	}
+
+	labelPattern := regexp.MustCompile("^[a-z0-9_-]{0,63}$")
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			name, labels := testCase.namer.NewResourceNameWithLabels(testCase.serviceName, testCase.resourceType, testCase.maxLength)
+
+			if name != testCase.expectedName {
+				t.Errorf("NewResourceNameWithLabels() name = %v, want %v", name, testCase.expectedName)
+			}
+
+			if labels[namer.LabelHash] == "" {
+				t.Errorf("NewResourceNameWithLabels() missing %s label", namer.LabelHash)
+			}
+
+			withoutHash := maps.Clone(labels)
+			delete(withoutHash, namer.LabelHash)
+			if !maps.Equal(withoutHash, testCase.expectedLabels) {
+				t.Errorf("NewResourceNameWithLabels() labels = %v, want %v", withoutHash, testCase.expectedLabels)
+			}
+
+			for key, value := range labels {
+				if !labelPattern.MatchString(key) || !labelPattern.MatchString(value) {
+					t.Errorf("NewResourceNameWithLabels() label %s=%s is not a valid label", key, value)
+				}
+			}
+		})
+	}
+}
+
+func TestNewResourceNameWithLabels_Hash(t *testing.T) {
+	t.Parallel()
+
+	n := namer.New("my-prod-stack")
+
+	_, first := n.NewResourceNameWithLabels("backend-processor", "service-account", 20)
+	_, again := n.NewResourceNameWithLabels("backend-processor", "service-account", 30)
+	_, other := n.NewResourceNameWithLabels("backend-processors", "service-account", 20)
+
+	if first[namer.LabelHash] != again[namer.LabelHash] {
+		t.Errorf("hash = %s, want the same hash %s regardless of truncation", again[namer.LabelHash], first[namer.LabelHash])
+	}
+
+	if first[namer.LabelHash] == other[namer.LabelHash] {
+		t.Errorf("hash = %s, want a different hash for a different name", other[namer.LabelHash])
+	}
+}
+
+func TestNewLabel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		key           string
+		value         string
+		expectedKey   string
+		expectedValue string
+	}{
+		{
+			name:          "valid label unchanged",
+			key:           "cost-center",
+			value:         "platform_team",
+			expectedKey:   "cost-center",
+			expectedValue: "platform_team",
+		},
+		{
+			name:          "uppercase and special characters",
+			key:           "Cost.Center",
+			value:         "Platform Team/Infra",
+			expectedKey:   "cost-center",
+			expectedValue: "platform-team-infra",
+		},
+		{
+			name:          "empty value allowed",
+			key:           "managed",
+			value:         "",
+			expectedKey:   "managed",
+			expectedValue: "",
+		},
+		{
+			name:          "international lowercase letters",
+			key:           "Équipe",
+			value:         "Données",
+			expectedKey:   "équipe",
+			expectedValue: "données",
+		},
+		{
+			name:          "leading underscore trimmed",
+			key:           "_team",
+			value:         "_platform",
+			expectedKey:   "team",
+			expectedValue: "_platform",
+		},
+		{
+			name:          "leading space and hyphen trimmed",
+			key:           " -Team",
+			value:         "Platform",
+			expectedKey:   "team",
+			expectedValue: "platform",
+		},
+		{
+			name:          "leading digits trimmed",
+			key:           "9lives",
+			value:         "9",
+			expectedKey:   "lives",
+			expectedValue: "9",
+		},
+		{
+			name:          "truncated to 63 characters with a hash",
+			key:           "a-very-long-label-key-that-goes-well-beyond-the-gcp-limit-of-sixty-three",
+			value:         "ÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄÄ",
+			expectedKey:   "a-very-long-label-key-that-goes-well-beyond-the-gcp-li-5ddd095b",
+			expectedValue: "ääääääääääääääääääääääääääääääääääääääääääääääääääääää-e5b3e869",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			key, value, err := namer.NewLabel(testCase.key, testCase.value)
+			if err != nil {
+				t.Fatalf("NewLabel() error = %v", err)
+			}
+
+			if key != testCase.expectedKey || value != testCase.expectedValue {
+				t.Errorf("NewLabel() = %q, %q, want %q, %q", key, value, testCase.expectedKey, testCase.expectedValue)
+			}
+		})
+	}
+}
+
+func TestNewLabel_DistinctAfterTruncation(t *testing.T) {
+	t.Parallel()
+
+	prefix := strings.Repeat("worker-pool-", 6)
+	_, first, _ := namer.NewLabel("pool", prefix+"10")
+	_, second, _ := namer.NewLabel("pool", prefix+"11")
+
+	if first == second {
+		t.Errorf("NewLabel() value = %v for both values, want distinct values", first)
+	}
+}
+
+func TestNewLabel_InvalidKey(t *testing.T) {
+	t.Parallel()
+
+	for _, key := range []string{"", "123", "-_ "} {
+		t.Run(key, func(t *testing.T) {
+			t.Parallel()
+
+			if _, _, err := namer.NewLabel(key, "value"); err == nil {
+				t.Errorf("NewLabel(%q) expected an error", key)
+			}
+		})
+	}
+}
+
+func TestNewLabels(t *testing.T) {
+	t.Parallel()
+
+	labels, err := namer.NewLabels(map[string]string{
+		"Team":        "Platform",
+		"cost_center": "cc-1234",
+		"env":         "",
+	})
+	if err != nil {
+		t.Fatalf("NewLabels() error = %v", err)
+	}
+
+	expected := map[string]string{"team": "platform", "cost_center": "cc-1234", "env": ""}
+	if !maps.Equal(labels, expected) {
+		t.Errorf("NewLabels() = %v, want %v", labels, expected)
+	}
+}
+
+func TestNewLabels_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tooMany := make(map[string]string, namer.MaxLabels+1)
+	for i := range namer.MaxLabels + 1 {
+		tooMany[fmt.Sprintf("label-%d", i)] = "value"
+	}
+
+	tests := []struct {
+		name           string
+		labels         map[string]string
+		expectedErrors []string
+	}{
+		{
+			name:           "too many labels",
+			labels:         tooMany,
+			expectedErrors: []string{"65 labels exceed the limit of 64"},
+		},
+		{
+			name:   "keys colliding after normalization",
+			labels: map[string]string{"Team": "a", "team": "b"},
+			expectedErrors: []string{
+				`label keys "Team" and "team" are both normalized to "team"`,
+			},
+		},
+		{
+			name:   "every invalid key is reported",
+			labels: map[string]string{"123": "a", "": "b", "valid": "c"},
+			expectedErrors: []string{
+				"label key must not be empty",
+				`label key "123" must contain a letter`,
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			labels, err := namer.NewLabels(testCase.labels)
+			if err == nil {
+				t.Fatalf("NewLabels() = %v, want an error", labels)
+			}
+
+			for _, expected := range testCase.expectedErrors {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("NewLabels() error = %v, want it to contain %q", err, expected)
+				}
+			}
+		})
+	}
+}
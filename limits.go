@@ -0,0 +1,23 @@
+package namer
+
+// WithLimits caps the max length of names by resource type. E.g.:
+// {"storage-account": 24, "key-vault": 24}. Limits match the resource type as
+// it appears in the name, after abbreviations and WithReplace. The lower of the
+// limit and the max length of the call is used.
+func WithLimits(limits map[string]int) Option {
+	return func(n *Namer) {
+		n.limits = limits
+	}
+}
+
+// limitMaxLength caps the max length with the limit of the normalized
+// resource type.
+func (e Namer) limitMaxLength(maxLength int, segments []Segment) int {
+	for _, segment := range segments {
+		if limit, ok := e.limits[segment.Value]; ok && segment.Name == SegmentType && limit > 0 {
+			return min(maxLength, limit)
+		}
+	}
+
+	return maxLength
+}
@@ -0,0 +1,23 @@
+package namer
+
+import (
+	"log/slog"
+)
+
+// WithLogger sets the logger for naming events. Names that are not valid are
+// logged at error level before panicking; replacements, truncation and
+// suffixes are logged at debug level. Defaults to slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(n *Namer) {
+		n.logger = logger
+	}
+}
+
+// log returns the logger set with WithLogger or the default logger.
+func (e Namer) log() *slog.Logger {
+	if e.logger == nil {
+		return slog.Default()
+	}
+
+	return e.logger
+}
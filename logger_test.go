@@ -0,0 +1,116 @@
+package namer_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+)
+
+// newBufferLogger returns a logger writing JSON records of every level to buffer.
+func newBufferLogger(buffer *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(buffer, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+// logMessages returns the messages of the JSON records in buffer.
+func logMessages(t *testing.T, buffer *bytes.Buffer) []string {
+	t.Helper()
+
+	var messages []string
+	for line := range strings.SplitSeq(strings.TrimSpace(buffer.String()), "\n") {
+		var record struct {
+			Msg string `json:"msg"`
+		}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("failed to decode log record %q: %v", line, err)
+		}
+		messages = append(messages, record.Msg)
+	}
+
+	return messages
+}
+
+func TestWithLogger_DebugEvents(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		opts             []namer.Option
+		serviceName      string
+		resourceType     string
+		maxLength        int
+		expectedMessages []string
+	}{
+		{
+			name:             "no events",
+			serviceName:      "orders",
+			resourceType:     "bucket",
+			maxLength:        63,
+			expectedMessages: nil,
+		},
+		{
+			name:             "replacement",
+			opts:             []namer.Option{namer.WithReplace()},
+			serviceName:      "orders_api",
+			resourceType:     "bucket",
+			maxLength:        63,
+			expectedMessages: []string{"Replaced characters in segment"},
+		},
+		{
+			name:             "truncation",
+			serviceName:      "backend-processor",
+			resourceType:     "service-account",
+			maxLength:        30,
+			expectedMessages: []string{"Truncated resource name"},
+		},
+		{
+			name:             "suffix",
+			opts:             []namer.Option{namer.WithKeySuffix("acme-prod", 6)},
+			serviceName:      "orders",
+			resourceType:     "bucket",
+			maxLength:        63,
+			expectedMessages: []string{"Generated name suffix"},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buffer bytes.Buffer
+			opts := append([]namer.Option{namer.WithLogger(newBufferLogger(&buffer))}, testCase.opts...)
+			namer.New("my-prod-stack", opts...).NewResourceName(testCase.serviceName, testCase.resourceType, testCase.maxLength)
+
+			var messages []string
+			if buffer.Len() > 0 {
+				messages = logMessages(t, &buffer)
+			}
+
+			if strings.Join(messages, ",") != strings.Join(testCase.expectedMessages, ",") {
+				t.Errorf("logged messages = %v, want %v", messages, testCase.expectedMessages)
+			}
+		})
+	}
+}
+
+func TestWithLogger_InvalidName(t *testing.T) {
+	t.Parallel()
+
+	var buffer bytes.Buffer
+	n := namer.New("Invalid", namer.WithLogger(newBufferLogger(&buffer)))
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic for an invalid name")
+		}
+
+		if messages := logMessages(t, &buffer); len(messages) != 1 || messages[0] != "Not a valid resource name" {
+			t.Errorf("logged messages = %v, want the validation error", messages)
+		}
+	}()
+
+	n.NewResourceName("orders", "bucket", 63)
+}
@@ -2,18 +2,49 @@
 package namer
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"math"
-	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 )
 
 // Namer provides consistent resource naming with length constraints
 type Namer struct {
 	baseName string
-	// If true, periods and underscores will be replaced with dashes
+	// If true, periods and underscores will be replaced with the separator
 	replace bool
+	// Separator between name components. Defaults to a hyphen
+	separator string
+	// Optional layout of the name. Segments are joined in order when nil
+	template *Template
+	// Values for template placeholders set for all names
+	values map[string]string
+	// Abbreviated environment added to all names
+	environment string
+	// Region code added to all names
+	region string
+	// Length of the suffix added to all names
+	suffixLength int
+	// Source of random suffixes. Defaults to crypto/rand
+	random io.Reader
+	// Key the suffix is derived from instead of being random
+	suffixKey *string
+	// Logger for naming events. Defaults to slog.Default()
+	logger *slog.Logger
+	// Hooks for naming events
+	hooks Hooks
+	// Naming rules of names. Defaults to RFC 1035
+	profile *Profile
+	// Abbreviations of words in segments
+	abbreviations map[string]string
+	// Max length of names by resource type
+	limits map[string]int
+	// Truncation strategy. Defaults to TruncationPriority
+	truncation string
 }
 
 // Option is a function that can be used to configure the Namer
@@ -21,7 +52,7 @@ type Option func(*Namer)
 
 // New creates a new Namer instance with the given base name
 func New(baseName string, opts ...Option) Namer {
-	n := Namer{baseName: baseName}
+	n := Namer{baseName: baseName, separator: "-"}
 	for _, opt := range opts {
 		opt(&n)
 	}
@@ -29,129 +60,448 @@ func New(baseName string, opts ...Option) Namer {
 	return n
 }
 
-// WithReplace replaces periods with dashes
+// WithReplace replaces periods, underscores, slashes and hyphens with the separator
+// and converts to lowercase
 func WithReplace() Option {
 	return func(n *Namer) {
 		n.replace = true
 	}
 }
 
+// WithSeparator sets the separator between name components. E.g.: "_", "." or
+// "" for no separator at all. Hyphens within components are left as is.
+func WithSeparator(separator string) Option {
+	return func(n *Namer) {
+		n.separator = separator
+	}
+}
+
+// WithTruncation sets the truncation strategy, either TruncationPriority or
+// TruncationProportional to truncate every segment by the same factor from
+// the start. Defaults to TruncationPriority.
+func WithTruncation(strategy string) Option {
+	return func(n *Namer) {
+		n.truncation = strategy
+	}
+}
+
 // NewResourceName generates a consistent resource name with length limits.
 func (e Namer) NewResourceName(resourceName, resourceType string, maxLength int) string {
-	// replace common characters on resource name and type parts
+	return e.NewName(maxLength,
+		Segment{Name: SegmentName, Value: resourceName},
+		Segment{Name: SegmentType, Value: resourceType},
+	)
+}
+
+// NewResourceNames generates count names for a family of resources, such as
+// replicas or shards. E.g.: worker-0 through worker-15. The index follows the
+// resource name and is never truncated, so no two indices collapse into the
+// same name. Templates must place it with {index}. The count must not be
+// negative.
+func (e Namer) NewResourceNames(resourceName, resourceType string, count, maxLength int) []string {
+	if count < 0 {
+		err := fmt.Errorf("count must not be negative, got %d", count)
+		e.log().Error("Not a valid resource count", "count", count, "error", err)
+		panic(err.Error())
+	}
+
+	names := make([]string, count)
+	indices := make(map[string]int, count)
+	for i := range count {
+		names[i] = e.mustGenerate(maxLength, []Segment{
+			{Name: SegmentName, Value: resourceName},
+			{Name: SegmentIndex, Value: strconv.Itoa(i), Fixed: true},
+			{Name: SegmentType, Value: resourceType},
+		})
+
+		if other, ok := indices[names[i]]; ok {
+			err := e.notifyCollision(names[i], fmt.Sprintf("index %d", other), fmt.Sprintf("index %d", i))
+			e.log().Error("Not a valid resource name", "name", names[i], "error", err)
+			panic(err.Error())
+		}
+		indices[names[i]] = i
+	}
+	e.notifyGenerated(names...)
+
+	return names
+}
+
+// NewName generates a resource name from an ordered list of segments, prefixed
+// with the base name. Segments are truncated according to their priority to
+// ensure max length.
+func (e Namer) NewName(maxLength int, segments ...Segment) string {
+	name := e.mustGenerate(maxLength, segments)
+	e.notifyGenerated(name)
+
+	return name
+}
+
+// mustGenerate generates the name, panicking when it is not valid.
+func (e Namer) mustGenerate(maxLength int, segments []Segment) string {
+	name, _, err := e.generate(maxLength, segments)
+	if err != nil {
+		e.log().Error("Not a valid resource name", "name", name, "error", err)
+		panic(panicValue(err))
+	}
+
+	return name
+}
+
+// generate lays out, truncates and validates the name. It also returns the
+// laid out segments before truncation. Callers notify the names they return
+// as generated, once every check passed.
+func (e Namer) generate(maxLength int, segments []Segment) (string, []Segment, error) {
+	explanation := e.explain(maxLength, segments)
+	e.notify(explanation)
+
+	return explanation.Name, explanation.segments, explanation.err
+}
+
+// explain lays out, truncates and validates the name, recording every step.
+func (e Namer) explain(maxLength int, segments []Segment) Explanation {
+	explanation := Explanation{Truncation: TruncationNone}
+	inputs := segments
+
+	// abbreviations match the words as given, before replacements
+	if len(e.abbreviations) > 0 {
+		segments = e.applyAbbreviations(segments)
+	}
+
+	// replace common characters on every segment except the base name
 	if e.replace {
-		resourceName, resourceType = applyReplacements(resourceName, resourceType)
+		segments = e.applyReplacements(segments)
+	}
+
+	// limits match the resource type as it appears in the name
+	maxLength = e.limitMaxLength(maxLength, segments)
+	explanation.MaxLength = maxLength
+
+	explanation.Replacements = replacements(inputs, segments)
+	for _, replacement := range explanation.Replacements {
+		e.log().Debug("Replaced characters in segment",
+			"segment", replacement.Segment, "from", replacement.From, "to", replacement.To)
+	}
+
+	segments, err := e.layout(segments)
+	if err != nil {
+		explanation.fail(err)
+
+		return explanation
 	}
+	explanation.segments = segments
+
+	truncated := segments
+	name := e.join(segmentValues(segments)...)
+
+	// fixed segments are never truncated, so they must fit on their own
+	if fixedLength := e.fixedLength(segments); len(name) > maxLength && fixedLength > maxLength {
+		explanation.Name = name
+		explanation.Components = components(inputs, segments, segments)
+		explanation.fail(fmt.Errorf("fixed segments need %d characters, max length is %d", fixedLength, maxLength))
 
-	var name string
-	if resourceType == "" {
-		name = fmt.Sprintf("%s-%s", e.baseName, resourceName)
-	} else {
-		name = fmt.Sprintf("%s-%s-%s", e.baseName, resourceName, resourceType)
+		return explanation
 	}
 
 	if len(name) > maxLength {
-		surplus := len(name) - maxLength
-		name = e.truncateResourceName(resourceName, resourceType, surplus, maxLength)
+		explanation.Surplus = len(name) - maxLength
+		truncated = e.truncateResourceName(segments, explanation.Surplus, maxLength, &explanation)
+		name = e.join(segmentValues(truncated)...)
+
+		e.log().Debug("Truncated resource name",
+			"name", name, "untruncated", e.join(segmentValues(segments)...), "maxLength", maxLength,
+			"surplus", explanation.Surplus, "truncation", explanation.Truncation, "factor", explanation.Factor)
 	}
+	explanation.Name = name
+	explanation.Components = components(inputs, segments, truncated)
+
+	if ok, err := e.isValidName(name); !ok {
+		explanation.fail(validationError{err})
 
-	if ok, err := isValidName(name); !ok {
-		slog.Error("Not a valid resource name", "name", name, "error", err)
-		panic("Resource name must start with a letter and end with a letter or digit")
+		return explanation
 	}
+	explanation.Valid = true
 
-	return name
+	return explanation
+}
+
+// layout arranges the segments in the final name order, either prefixed with
+// the base name or as set by the template.
+func (e Namer) layout(segments []Segment) ([]Segment, error) {
+	segments = append(e.contextSegments(), segments...)
+
+	suffix, err := e.suffixSegments(append([]Segment{e.baseSegment()}, segments...))
+	if err != nil {
+		return nil, err
+	}
+
+	if e.template == nil {
+		laidOut := append([]Segment{e.baseSegment()}, segments...)
+
+		return append(laidOut, suffix...), nil
+	}
+
+	if err := e.template.checkSegments(segments); err != nil {
+		return nil, err
+	}
+
+	// the suffix goes at the end unless the template places it
+	if e.template.hasKey(SegmentSuffix) {
+		return e.template.render(e.templateValues(append(segments, suffix...)))
+	}
+
+	laidOut, err := e.template.render(e.templateValues(segments))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(laidOut, suffix...), nil
 }
 
-// applyReplacements replaces common characters and converts to lowercase
-func applyReplacements(resourceName string, resourceType string) (string, string) {
-	resourceName = strings.ReplaceAll(resourceName, ".", "-")
-	resourceName = strings.ReplaceAll(resourceName, "_", "-")
-	resourceType = strings.ReplaceAll(resourceType, ".", "-")
-	resourceType = strings.ReplaceAll(resourceType, "_", "-")
-	resourceName = strings.ReplaceAll(resourceName, "/", "-")
-	resourceType = strings.ReplaceAll(resourceType, "/", "-")
+// contextSegments returns the segments set on the Namer for all names.
+func (e Namer) contextSegments() []Segment {
+	var segments []Segment
+	if e.environment != "" {
+		segments = append(segments, e.environmentSegment())
+	}
+	if e.region != "" {
+		segments = append(segments, e.regionSegment())
+	}
 
-	// convert to lowercase
-	resourceName = strings.ToLower(resourceName)
-	resourceType = strings.ToLower(resourceType)
+	return segments
+}
 
-	return resourceName, resourceType
+// baseSegment returns the base name as the leading segment of every name.
+func (e Namer) baseSegment() Segment {
+	return Segment{Name: SegmentBase, Value: e.baseName, Priority: basePriority}
 }
 
-// isValidName validates the final name in accord with RFC 1035.
+// applyReplacements replaces common characters and converts to lowercase.
+// Hyphens are replaced as well when using a different separator.
+func (e Namer) applyReplacements(segments []Segment) []Segment {
+	replacer := strings.NewReplacer(".", e.separator, "_", e.separator, "/", e.separator, "-", e.separator)
+
+	replaced := make([]Segment, len(segments))
+	for i, segment := range segments {
+		value := replacer.Replace(segment.Value)
+
+		// convert to lowercase
+		segment.Value = strings.ToLower(value)
+		replaced[i] = segment
+	}
+
+	return replaced
+}
+
+// isValidName validates the final name with the profile rules, RFC 1035 by
+// default, allowing the separator as an interior character.
 // See: https://cloud.google.com/compute/docs/naming-resources
-func isValidName(name string) (ok bool, err error) {
+func (e Namer) isValidName(name string) (ok bool, err error) {
 	// validate final name in accord with RFC 1035:
 	// - Must start with a letter
-	// - Can contain letters, digits, and hyphens as interior characters
+	// - Can contain letters, digits, hyphens and the separator as interior characters
 	// - Must end with a letter or digit (cannot end with a hyphen)
 	// - Maximum length of 63 characters
-	matched, _ := regexp.MatchString("^[a-z]([-a-z0-9]*[a-z0-9])?$", name)
-	if !matched {
-		return false, fmt.Errorf("name must start with a letter and end with a letter or digit")
+	if err := e.profileRules().Validate(name); err != nil {
+		return false, err
 	}
 
-	if len(name) > 63 {
-		return false, fmt.Errorf("name must be less than 63 characters")
+	return true, nil
+}
+
+// invalidNameMessage is the panic value of names that fail validation.
+const invalidNameMessage = "Resource name must start with a letter and end with a letter or digit"
+
+// validationError is a name that fails validation with the profile rules.
+type validationError struct {
+	err error
+}
+
+// Error returns the message of the validation error.
+func (v validationError) Error() string {
+	return v.err.Error()
+}
+
+// Unwrap returns the validation error.
+func (v validationError) Unwrap() error {
+	return v.err
+}
+
+// panicValue returns the panic value of the error. Names that fail validation
+// keep panicking with the same message, so callers matching it still work.
+func panicValue(err error) string {
+	if errors.As(err, &validationError{}) {
+		return invalidNameMessage
 	}
 
-	return true, nil
+	return err.Error()
 }
 
 // truncateResourceName truncates and handles max length constraints.
-func (e Namer) truncateResourceName(resourceName, resourceType string, surplus, maxLength int) string {
-	mainComponentLength := len(e.baseName)
-	if mainComponentLength > surplus {
-		return e.truncateMainComponent(resourceName, resourceType, surplus)
+//
+// Segments with the highest priority are truncated first as long as they are
+// long enough to absorb the surplus on their own. Otherwise, the next priority
+// is added to the pool. When no pool is long enough, or with
+// TruncationProportional, all segments are truncated proportionally. The
+// strategy taken is recorded in the trace.
+func (e Namer) truncateResourceName(segments []Segment, surplus, maxLength int, trace *Explanation) []Segment {
+	priorities := segmentPriorities(segments)
+	if e.truncation == TruncationProportional {
+		priorities = nil
+	}
+
+	// the lowest priority is left out as it amounts to proportional truncation
+	for i := range len(priorities) - 1 {
+		pool := make(map[int]bool)
+		poolLength := 0
+		for j, segment := range segments {
+			if !segment.Fixed && segment.Priority >= priorities[i] {
+				pool[j] = true
+				poolLength += len(segment.Value)
+			}
+		}
+
+		if poolLength > surplus {
+			trace.Truncation = TruncationPriority
+
+			return e.truncateMainComponent(segments, pool, poolLength, surplus)
+		}
 	}
+	trace.Truncation = TruncationProportional
 
-	return e.proportionalTruncate(resourceName, resourceType, maxLength)
+	return e.proportionalTruncate(segments, maxLength, trace)
 }
 
-// truncateMainComponent truncates the main component name when it's long enough.
-func (e Namer) truncateMainComponent(resourceName, resourceType string, surplus int) string {
-	truncatedMainComponent := e.baseName[:len(e.baseName)-surplus]
-	truncatedMainComponent = trimTrailingHyphen(truncatedMainComponent)
+// truncateMainComponent truncates the pool of main components when they're
+// long enough, distributing the surplus in proportion to their lengths.
+func (e Namer) truncateMainComponent(segments []Segment, pool map[int]bool, poolLength, surplus int) []Segment {
+	budget := poolLength - surplus
+
+	lengths := make(map[int]int, len(pool))
+	remaining := budget
+	for i := range segments {
+		if pool[i] {
+			lengths[i] = len(segments[i].Value) * budget / poolLength
+			remaining -= lengths[i]
+		}
+	}
+
+	// hand out the rounding leftovers in segment order
+	for i := 0; remaining > 0; i = (i + 1) % len(segments) {
+		if pool[i] && lengths[i] < len(segments[i].Value) {
+			lengths[i]++
+			remaining--
+		}
+	}
 
-	if resourceType == "" {
-		return fmt.Sprintf("%s-%s", truncatedMainComponent, resourceName)
+	truncated := make([]Segment, len(segments))
+	for i, segment := range segments {
+		if pool[i] {
+			segment.Value = e.trimTrailingSeparator(segment.Value[:lengths[i]])
+		}
+		truncated[i] = segment
 	}
 
-	return fmt.Sprintf("%s-%s-%s", truncatedMainComponent, resourceName, resourceType)
+	return truncated
 }
 
 // proportionalTruncate applies proportional truncation when main component is too short.
-func (e Namer) proportionalTruncate(resourceName, resourceType string, maxLength int) string {
-	originalLength := len(join(e.baseName, resourceName, resourceType))
+// Without a base name, the full budget goes to the remaining segments. Fixed
+// segments keep their length and are taken out of the budget. The truncation
+// factor is recorded in the trace.
+func (e Namer) proportionalTruncate(segments []Segment, maxLength int, trace *Explanation) []Segment {
+	originalLength := len(e.join(segmentValues(segments)...))
 
-	truncateFactorFloat := float64(maxLength) / float64(originalLength)
-	truncateFactor := math.Floor(truncateFactorFloat*100) / 100
+	fixedLength := 0
+	for _, segment := range segments {
+		if segment.Fixed {
+			fixedLength += len(segment.Value)
+		}
+	}
 
-	mainComponentLength := int(math.Floor(float64(len(e.baseName)) * truncateFactor))
-	resourceNameLength := int(math.Floor(float64(len(resourceName)) * truncateFactor))
-	resourceTypeLength := int(math.Floor(float64(len(resourceType)) * truncateFactor))
+	truncateFactor := 0.0
+	if truncatableLength := originalLength - fixedLength; truncatableLength > 0 {
+		truncateFactorFloat := float64(maxLength-fixedLength) / float64(truncatableLength)
+		truncateFactor = max(math.Floor(truncateFactorFloat*100)/100, 0)
+	}
+	trace.Factor = truncateFactor
 
 	// Truncate each component and remove trailing hyphens
-	truncatedBaseName := trimTrailingHyphen(e.baseName[:mainComponentLength])
-	truncatedResourceName := trimTrailingHyphen(resourceName[:resourceNameLength])
-	truncatedResourceType := trimTrailingHyphen(resourceType[:resourceTypeLength])
+	truncated := make([]Segment, len(segments))
+	for i, segment := range segments {
+		if !segment.Fixed {
+			length := int(math.Floor(float64(len(segment.Value)) * truncateFactor))
+			segment.Value = e.trimTrailingSeparator(segment.Value[:length])
+		}
+		truncated[i] = segment
+	}
+
+	// separators don't shrink with the factor, so many segments can still
+	// overflow. Shave the longest segments until the name fits.
+	for len(e.join(segmentValues(truncated)...)) > maxLength {
+		longest := -1
+		for i, segment := range truncated {
+			if !segment.Fixed && segment.Value != "" &&
+				(longest < 0 || len(segment.Value) > len(truncated[longest].Value)) {
+				longest = i
+			}
+		}
+		if longest < 0 {
+			break
+		}
+		truncated[longest].Value = e.trimTrailingSeparator(truncated[longest].Value[:len(truncated[longest].Value)-1])
+	}
+
+	return truncated
+}
+
+// fixedLength returns the length of the fixed segments joined, the least a
+// name can be truncated to.
+func (e Namer) fixedLength(segments []Segment) int {
+	var fixed []string
+	for _, segment := range segments {
+		if segment.Fixed {
+			fixed = append(fixed, segment.Value)
+		}
+	}
+
+	return len(e.join(fixed...))
+}
+
+// segmentPriorities returns the distinct segment priorities, highest first.
+func segmentPriorities(segments []Segment) []int {
+	seen := make(map[int]bool)
+	priorities := make([]int, 0, len(segments))
+	for _, segment := range segments {
+		if !segment.Fixed && segment.Value != "" && !seen[segment.Priority] {
+			seen[segment.Priority] = true
+			priorities = append(priorities, segment.Priority)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(priorities)))
 
-	return join(truncatedBaseName, truncatedResourceName, truncatedResourceType)
+	return priorities
 }
 
-// join composes the base name, resource name and resource type in the final format.
-func join(baseName string, resourceName string, resourceType string) string {
-	if resourceType == "" {
-		return fmt.Sprintf("%s-%s", baseName, resourceName)
+// join composes the name components in the final format.
+// Empty components are skipped so that no dangling separators are left behind.
+func (e Namer) join(components ...string) string {
+	nonEmpty := make([]string, 0, len(components))
+	for _, component := range components {
+		if component != "" {
+			nonEmpty = append(nonEmpty, component)
+		}
 	}
 
-	return fmt.Sprintf("%s-%s-%s", baseName, resourceName, resourceType)
+	return strings.Join(nonEmpty, e.separator)
 }
 
-// trimTrailingHyphen removes trailing hyphens from a string component
-func trimTrailingHyphen(component string) string {
-	for len(component) > 0 && component[len(component)-1] == '-' {
+// trimTrailingSeparator removes trailing separators and hyphens from a string component
+func (e Namer) trimTrailingSeparator(component string) string {
+	for len(component) > 0 {
+		last := component[len(component)-1:]
+		if last != "-" && last != e.separator {
+			break
+		}
 		component = component[:len(component)-1]
 	}
 
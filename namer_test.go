@@ -1,6 +1,8 @@
 package namer_test
 
 import (
+	"fmt"
+	"slices"
 	"strings"
 	"testing"
 
@@ -774,3 +776,355 @@ func TestNewResourceName_WithReplace(t *testing.T) {
 		})
 	}
 }
+
+func TestNewResourceName_WithoutBaseName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		serviceName  string
+		resourceType string
+		maxLength    int
+		expected     string
+	}{
+		{
+			name:         "no truncation",
+			serviceName:  "orders",
+			resourceType: "bucket",
+			maxLength:    63,
+			expected:     "orders-bucket",
+		},
+		{
+			name:         "no resource type",
+			serviceName:  "orders",
+			resourceType: "",
+			maxLength:    63,
+			expected:     "orders",
+		},
+		{
+			name:         "full budget goes to name and type",
+			serviceName:  "backend-processor",
+			resourceType: "service-account",
+			maxLength:    20,
+			expected:     "backend-pr-service-a",
+		},
+		{
+			name:         "truncated name without type",
+			serviceName:  "very-long-service-name",
+			resourceType: "",
+			maxLength:    10,
+			expected:     "very-long",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			n := namer.New("")
+			result := n.NewResourceName(testCase.serviceName, testCase.resourceType, testCase.maxLength)
+
+			if result != testCase.expected {
+				t.Errorf("NewResourceName() = %v, want %v", result, testCase.expected)
+			}
+
+			if len(result) > testCase.maxLength {
+				t.Errorf("NewResourceName() length = %d, want <= %d",
+					len(result), testCase.maxLength)
+			}
+		})
+	}
+}
+
+func TestNewResourceName_WithSeparator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		baseName     string
+		separator    string
+		replace      bool
+		serviceName  string
+		resourceType string
+		maxLength    int
+		expected     string
+	}{
+		{
+			name:         "underscore separator",
+			baseName:     "myprod",
+			separator:    "_",
+			serviceName:  "orders",
+			resourceType: "bucket",
+			maxLength:    63,
+			expected:     "myprod_orders_bucket",
+		},
+		{
+			name:         "period separator",
+			baseName:     "myprod",
+			separator:    ".",
+			serviceName:  "orders",
+			resourceType: "bucket",
+			maxLength:    63,
+			expected:     "myprod.orders.bucket",
+		},
+		{
+			name:         "no separator",
+			baseName:     "myprod",
+			separator:    "",
+			serviceName:  "orders",
+			resourceType: "bucket",
+			maxLength:    24,
+			expected:     "myprodordersbucket",
+		},
+		{
+			name:         "hyphens within components are kept",
+			baseName:     "myprod",
+			separator:    "_",
+			serviceName:  "backend-processor",
+			resourceType: "service-account",
+			maxLength:    25,
+			expected:     "myp_backend-pr_service-a",
+		},
+		{
+			name:         "truncated base trims trailing separator",
+			baseName:     "cloudflare_edge_waf",
+			separator:    "_",
+			serviceName:  "zone",
+			resourceType: "dns",
+			maxLength:    15,
+			expected:     "cloudf_zone_dns",
+		},
+		{
+			name:         "replace with underscore separator",
+			baseName:     "myprod",
+			separator:    "_",
+			replace:      true,
+			serviceName:  "User.Auth-service",
+			resourceType: "api/gateway",
+			maxLength:    63,
+			expected:     "myprod_user_auth_service_api_gateway",
+		},
+		{
+			name:         "replace removes delimiters without separator",
+			baseName:     "myprod",
+			separator:    "",
+			replace:      true,
+			serviceName:  "User.Auth-service",
+			resourceType: "api/gateway",
+			maxLength:    63,
+			expected:     "myproduserauthserviceapigateway",
+		},
+		{
+			name:         "replace with truncation and trailing separator",
+			baseName:     "myprod",
+			separator:    "_",
+			replace:      true,
+			serviceName:  "backend_processor_",
+			resourceType: "service.account",
+			maxLength:    20,
+			expected:     "my_backend_service",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := []namer.Option{namer.WithSeparator(testCase.separator)}
+			if testCase.replace {
+				opts = append(opts, namer.WithReplace())
+			}
+
+			n := namer.New(testCase.baseName, opts...)
+			result := n.NewResourceName(testCase.serviceName, testCase.resourceType, testCase.maxLength)
+
+			if result != testCase.expected {
+				t.Errorf("NewResourceName() = %v, want %v", result, testCase.expected)
+			}
+
+			if len(result) > testCase.maxLength {
+				t.Errorf("NewResourceName() length = %d, want <= %d", len(result), testCase.maxLength)
+			}
+
+			if testCase.separator != "" && strings.HasSuffix(result, testCase.separator) {
+				t.Errorf("NewResourceName() = %s, should not have a trailing separator", result)
+			}
+		})
+	}
+}
+
+func TestNewResourceNames(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		baseName     string
+		resourceName string
+		resourceType string
+		count        int
+		maxLength    int
+		expected     []string
+	}{
+		{
+			name:         "no truncation",
+			baseName:     "my-prod-stack",
+			resourceName: "worker",
+			resourceType: "pod",
+			count:        3,
+			maxLength:    63,
+			expected:     []string{"my-prod-stack-worker-0-pod", "my-prod-stack-worker-1-pod", "my-prod-stack-worker-2-pod"},
+		},
+		{
+			name:         "index kept when truncating",
+			baseName:     "my-prod-stack",
+			resourceName: "worker",
+			resourceType: "pod",
+			count:        12,
+			maxLength:    12,
+			expected: []string{
+				"my-pr-wo-0-p", "my-pr-wo-1-p", "my-pr-wo-2-p", "my-pr-wo-3-p", "my-pr-wo-4-p", "my-pr-wo-5-p",
+				"my-pr-wo-6-p", "my-pr-wo-7-p", "my-pr-wo-8-p", "my-pr-wo-9-p", "my-p-wo-10-p", "my-p-wo-11-p",
+			},
+		},
+		{
+			name:         "no names",
+			baseName:     "my-prod-stack",
+			resourceName: "worker",
+			resourceType: "pod",
+			count:        0,
+			maxLength:    63,
+			expected:     []string{},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			n := namer.New(testCase.baseName)
+			result := n.NewResourceNames(testCase.resourceName, testCase.resourceType, testCase.count, testCase.maxLength)
+
+			if !slices.Equal(result, testCase.expected) {
+				t.Errorf("NewResourceNames() = %v, want %v", result, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestNewResourceNames_Distinct(t *testing.T) {
+	t.Parallel()
+
+	n := namer.New("cloudflare-edge-waf")
+	names := n.NewResourceNames("ingest-worker", "statefulset", 16, 30)
+
+	seen := make(map[string]bool, len(names))
+	for i, name := range names {
+		if seen[name] {
+			t.Errorf("NewResourceNames() generated %v more than once", name)
+		}
+		seen[name] = true
+
+		if !strings.Contains(name, fmt.Sprintf("-%d-", i)) {
+			t.Errorf("NewResourceNames()[%d] = %v, want it to contain the index", i, name)
+		}
+
+		if len(name) > 30 {
+			t.Errorf("NewResourceNames()[%d] length = %d, want <= 30", i, len(name))
+		}
+	}
+}
+
+func TestNewResourceName_FixedSegmentsOverMaxLength(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		namer         namer.Namer
+		maxLength     int
+		expectedError string
+	}{
+		{
+			name: "suffix, environment and region",
+			namer: namer.New("acme",
+				namer.WithEnvironment("production"),
+				namer.WithRegion("northamerica-northeast1"),
+				namer.WithKeySuffix("orders", 6),
+			),
+			maxLength:     12,
+			expectedError: "fixed segments need 16 characters, max length is 12",
+		},
+		{
+			name:          "environment and region",
+			namer:         namer.New("acme", namer.WithEnvironment("production"), namer.WithRegion("us-central1")),
+			maxLength:     7,
+			expectedError: "fixed segments need 8 characters, max length is 7",
+		},
+		{
+			name:          "template literals",
+			namer:         namer.New("acme", namer.WithTemplate(namer.MustParseTemplate("{base}-managed-by-terraform-{name}-{type}"))),
+			maxLength:     16,
+			expectedError: "fixed segments need 20 characters, max length is 16",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			explanation := testCase.namer.Explain("orders", "bucket", testCase.maxLength)
+			if explanation.Valid || explanation.Error != testCase.expectedError {
+				t.Errorf("Explain() error = %q, want %q", explanation.Error, testCase.expectedError)
+			}
+
+			_, err := testCase.namer.NewResourceNameBatch([]namer.ResourceSpec{
+				{Name: "orders", Type: "bucket", MaxLength: testCase.maxLength},
+			})
+			if err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+				t.Errorf("NewResourceNameBatch() error = %v, want %q", err, testCase.expectedError)
+			}
+
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("NewResourceName() expected a panic")
+				}
+			}()
+			testCase.namer.NewResourceName("orders", "bucket", testCase.maxLength)
+		})
+	}
+}
+
+func TestNewResourceNames_IndexOverMaxLength(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "fixed segments need") {
+			t.Errorf("NewResourceNames() panic = %v, want fixed segments over the max length", r)
+		}
+	}()
+
+	n := namer.New("acme", namer.WithEnvironment("production"), namer.WithKeySuffix("workers", 6))
+	n.NewResourceNames("worker", "pod", 2, 10)
+}
+
+func TestNewResourceNames_NegativeCount(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r != "count must not be negative, got -1" {
+			t.Errorf("NewResourceNames() panic = %v, want a negative count error", r)
+		}
+	}()
+
+	namer.New("acme").NewResourceNames("worker", "pod", -1, 63)
+}
+
+func TestNewResourceName_InvalidNamePanicValue(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r != "Resource name must start with a letter and end with a letter or digit" {
+			t.Errorf("NewResourceName() panic = %v, want the invalid name message", r)
+		}
+	}()
+
+	namer.New("123-invalid").NewResourceName("service", "type", 50)
+}
@@ -0,0 +1,339 @@
+package namer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ParsedName holds the components recovered from a name generated by a Namer.
+type ParsedName struct {
+	// Base is the base name as found in the name.
+	Base string
+	// Name is the resource name.
+	Name string
+	// Type is the resource type or group.
+	Type string
+	// Segments holds every recovered segment by name, including env, region
+	// and template values.
+	Segments map[string]string
+	// Truncated is true when the name was found to be truncated. Truncation
+	// is only detected by a shortened base name, so it can't be trusted for
+	// Namers without a base name, where it is always false.
+	Truncated bool
+	// Ambiguous lists the segments that couldn't be recovered with certainty,
+	// either because they may be truncated or because the boundary between
+	// them is unknown.
+	Ambiguous []string
+}
+
+// parseElement is a segment of the layout, either known up front (base name,
+// environment, region, template values and literals) or to be recovered.
+// Segments of a known length, like suffixes, can be told apart at the ends.
+type parseElement struct {
+	key      string
+	value    string
+	known    bool
+	optional bool
+	length   int
+}
+
+// anchored reports whether the element can be matched at either end.
+func (p parseElement) anchored() bool {
+	return p.known || p.length > 0
+}
+
+// Parse recovers the segments of a name generated by the Namer.
+//
+// Names that weren't truncated are fully recovered, as long as the boundary
+// between segments can be told apart. As the resource type is optional, pass
+// the expected resource types to split it from the resource name; otherwise
+// both are reported as ambiguous when separators are found. Truncation is
+// detected by a shortened base name, in which case every recovered segment is
+// reported as ambiguous. Without a base name, truncated names can't be told
+// apart and are parsed as is.
+func (e Namer) Parse(name string, resourceTypes ...string) (ParsedName, error) {
+	parsed := ParsedName{Segments: make(map[string]string)}
+	elements := e.parseElements()
+
+	// consume known segments from both ends
+	rest := name
+	first, last := 0, len(elements)
+	for ; first < last && elements[first].anchored(); first++ {
+		length, truncated, ok := e.matchKnown(rest, elements[first], true)
+		if !ok {
+			return ParsedName{}, fmt.Errorf("name %q does not match %s", name, e.describeElement(elements[first]))
+		}
+		e.recordKnown(&parsed, elements[first], rest[:length], truncated)
+		rest = strings.TrimPrefix(rest[length:], e.separator)
+	}
+	for ; last > first && elements[last-1].anchored(); last-- {
+		length, truncated, ok := e.matchKnown(rest, elements[last-1], false)
+		if !ok {
+			return ParsedName{}, fmt.Errorf("name %q does not match %s", name, e.describeElement(elements[last-1]))
+		}
+		e.recordKnown(&parsed, elements[last-1], rest[len(rest)-length:], truncated)
+		rest = strings.TrimSuffix(rest[:len(rest)-length], e.separator)
+	}
+
+	// known segments in between delimit runs of segments to recover
+	var unknown, run []parseElement
+	for _, element := range elements[first:last] {
+		if !element.known {
+			run = append(run, element)
+			unknown = append(unknown, element)
+
+			continue
+		}
+
+		index, value, truncated := e.findKnown(rest, element)
+		if index < 0 {
+			return ParsedName{}, fmt.Errorf("name %q does not match %s", name, e.describeElement(element))
+		}
+		if err := e.splitUnknown(&parsed, run, rest[:index], resourceTypes); err != nil {
+			return ParsedName{}, fmt.Errorf("name %q can't be parsed: %w", name, err)
+		}
+		e.recordKnown(&parsed, element, value, truncated)
+		rest = rest[index+len(e.separator+value+e.separator):]
+		run = nil
+	}
+
+	if err := e.splitUnknown(&parsed, run, rest, resourceTypes); err != nil {
+		return ParsedName{}, fmt.Errorf("name %q can't be parsed: %w", name, err)
+	}
+
+	if parsed.Truncated {
+		for _, element := range unknown {
+			parsed.Ambiguous = append(parsed.Ambiguous, element.key)
+		}
+	}
+	slices.Sort(parsed.Ambiguous)
+	parsed.Ambiguous = slices.Compact(parsed.Ambiguous)
+
+	parsed.Base = parsed.Segments[SegmentBase]
+	parsed.Name = parsed.Segments[SegmentName]
+	parsed.Type = parsed.Segments[SegmentType]
+
+	return parsed, nil
+}
+
+// parseElements returns the layout of the names generated by the Namer.
+func (e Namer) parseElements() []parseElement {
+	known := map[string]string{SegmentBase: e.baseName}
+	for key, value := range e.values {
+		known[key] = value
+	}
+	for _, segment := range e.contextSegments() {
+		known[segment.Name] = segment.Value
+	}
+
+	var elements []parseElement
+	if e.template == nil {
+		elements = append(elements, parseElement{key: SegmentBase, value: e.baseName, known: true})
+		for _, segment := range e.contextSegments() {
+			elements = append(elements, parseElement{key: segment.Name, value: segment.Value, known: true})
+		}
+		elements = append(elements,
+			parseElement{key: SegmentName},
+			parseElement{key: SegmentType, optional: true},
+		)
+	} else {
+		for _, element := range e.template.elements {
+			if element.key == "" {
+				elements = append(elements, parseElement{value: element.literal, known: true})
+
+				continue
+			}
+			if element.key == SegmentSuffix {
+				elements = append(elements, parseElement{key: SegmentSuffix, length: e.suffixLength})
+
+				continue
+			}
+			value, ok := known[element.key]
+			elements = append(elements, parseElement{key: element.key, value: value, known: ok, optional: element.optional})
+		}
+	}
+
+	if e.suffixLength > 0 && (e.template == nil || !e.template.hasKey(SegmentSuffix)) {
+		elements = append(elements, parseElement{key: SegmentSuffix, length: e.suffixLength})
+	}
+
+	// known segments without value are left out of every name
+	return slices.DeleteFunc(elements, func(element parseElement) bool {
+		return element.known && element.value == ""
+	})
+}
+
+// matchKnown matches a known segment at the start or the end of the name and
+// returns its length. The base name may be found truncated.
+func (e Namer) matchKnown(name string, element parseElement, fromStart bool) (length int, truncated bool, ok bool) {
+	if !element.known {
+		return e.matchLength(name, element.length, fromStart)
+	}
+
+	for i, candidate := range e.knownCandidates(element) {
+		if candidate == "" {
+			continue
+		}
+		if fromStart && (name == candidate || strings.HasPrefix(name, candidate+e.separator)) {
+			return len(candidate), i > 0, true
+		}
+		if !fromStart && (name == candidate || strings.HasSuffix(name, e.separator+candidate)) {
+			return len(candidate), i > 0, true
+		}
+	}
+
+	return 0, false, false
+}
+
+// findKnown finds a known segment between two separators and returns its
+// index, the value found and whether it is truncated. The base name may be
+// found truncated. The index is -1 when it is not found.
+func (e Namer) findKnown(name string, element parseElement) (index int, value string, truncated bool) {
+	for i, candidate := range e.knownCandidates(element) {
+		if candidate == "" {
+			continue
+		}
+		if index := strings.Index(name, e.separator+candidate+e.separator); index >= 0 {
+			return index, candidate, i > 0
+		}
+	}
+
+	return -1, "", false
+}
+
+// knownCandidates returns the values a known segment may take, longest
+// first. Only the base name is truncated, so it may be found shortened.
+func (e Namer) knownCandidates(element parseElement) []string {
+	candidates := []string{element.value}
+	if element.key == SegmentBase {
+		for length := len(element.value) - 1; length > 0; length-- {
+			candidates = append(candidates, e.trimTrailingSeparator(element.value[:length]))
+		}
+	}
+
+	return candidates
+}
+
+// matchLength matches a segment of a known length at the start or the end of
+// the name.
+func (e Namer) matchLength(name string, length int, fromStart bool) (int, bool, bool) {
+	if len(name) < length {
+		return 0, false, false
+	}
+
+	if fromStart {
+		return length, false, len(name) == length || strings.HasPrefix(name[length:], e.separator)
+	}
+
+	return length, false, len(name) == length || strings.HasSuffix(name[:len(name)-length], e.separator)
+}
+
+// recordKnown records a matched known segment.
+func (e Namer) recordKnown(parsed *ParsedName, element parseElement, value string, truncated bool) {
+	if element.key == "" {
+		return
+	}
+	parsed.Segments[element.key] = value
+	if truncated {
+		parsed.Truncated = true
+		parsed.Ambiguous = append(parsed.Ambiguous, element.key)
+	}
+}
+
+// splitUnknown splits what's left of the name between the segments to recover.
+func (e Namer) splitUnknown(parsed *ParsedName, unknown []parseElement, rest string, resourceTypes []string) error {
+	if len(unknown) == 0 {
+		if rest != "" {
+			return fmt.Errorf("unexpected %q", rest)
+		}
+
+		return nil
+	}
+
+	// a known resource type settles the boundary with the preceding segment
+	if last := unknown[len(unknown)-1]; last.key == SegmentType && len(unknown) > 1 {
+		for _, resourceType := range resourceTypes {
+			if resourceType != "" && strings.HasSuffix(rest, e.separator+resourceType) {
+				parsed.Segments[SegmentType] = resourceType
+				rest = strings.TrimSuffix(rest, e.separator+resourceType)
+				unknown = unknown[:len(unknown)-1]
+
+				break
+			}
+		}
+	}
+
+	tokens := []string{rest}
+	if e.separator != "" {
+		tokens = strings.Split(rest, e.separator)
+	}
+
+	switch {
+	case rest == "":
+		return fmt.Errorf("missing %s", e.describeElement(unknown[0]))
+	case len(unknown) == 1:
+		parsed.Segments[unknown[0].key] = rest
+	case len(tokens) <= len(unknown) && allOptional(unknown[len(tokens):]):
+		for i, element := range unknown {
+			if i < len(tokens) {
+				parsed.Segments[element.key] = tokens[i]
+			}
+		}
+
+		// optional segments may be left out, in which case the tokens could
+		// as well belong to a single segment
+		if len(tokens) > 1 && anyOptional(unknown) {
+			for _, element := range unknown {
+				parsed.Ambiguous = append(parsed.Ambiguous, element.key)
+			}
+		}
+	default:
+		// boundaries are unknown. Right-most segments take one token each and
+		// the first one takes the rest.
+		extra := max(len(tokens)-len(unknown), 0)
+		for i, element := range unknown {
+			switch {
+			case i == 0:
+				parsed.Segments[element.key] = strings.Join(tokens[:extra+1], e.separator)
+			case extra+i < len(tokens):
+				parsed.Segments[element.key] = tokens[extra+i]
+			default:
+				parsed.Segments[element.key] = ""
+			}
+			parsed.Ambiguous = append(parsed.Ambiguous, element.key)
+		}
+	}
+
+	return nil
+}
+
+// allOptional reports whether every element is optional.
+func allOptional(elements []parseElement) bool {
+	for _, element := range elements {
+		if !element.optional {
+			return false
+		}
+	}
+
+	return true
+}
+
+// anyOptional reports whether any element is optional.
+func anyOptional(elements []parseElement) bool {
+	return slices.ContainsFunc(elements, func(element parseElement) bool {
+		return element.optional
+	})
+}
+
+// describeElement names a layout element for error messages.
+func (e Namer) describeElement(element parseElement) string {
+	if element.key == "" {
+		return fmt.Sprintf("literal %q", element.value)
+	}
+	if element.known {
+		return fmt.Sprintf("%s %q", element.key, element.value)
+	}
+
+	return element.key
+}
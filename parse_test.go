@@ -0,0 +1,221 @@
+package namer_test
+
+import (
+	"slices"
+	"testing"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		namer             namer.Namer
+		input             string
+		resourceTypes     []string
+		expectedSegments  map[string]string
+		expectedTruncated bool
+		expectedAmbiguous []string
+	}{
+		{
+			name:             "base name and type",
+			namer:            namer.New("my-prod-stack"),
+			input:            "my-prod-stack-orders-bucket",
+			resourceTypes:    []string{"bucket"},
+			expectedSegments: map[string]string{"base": "my-prod-stack", "name": "orders", "type": "bucket"},
+		},
+		{
+			name:              "optional type without resource types is ambiguous",
+			namer:             namer.New("acme", namer.WithEnvironment("production")),
+			input:             "acme-prd-orders-bucket",
+			expectedSegments:  map[string]string{"base": "acme", "env": "prd", "name": "orders", "type": "bucket"},
+			expectedAmbiguous: []string{"name", "type"},
+		},
+		{
+			name:             "no resource type",
+			namer:            namer.New("my-prod-stack"),
+			input:            "my-prod-stack-orders",
+			expectedSegments: map[string]string{"base": "my-prod-stack", "name": "orders"},
+		},
+		{
+			name:             "no base name",
+			namer:            namer.New(""),
+			input:            "orders-bucket",
+			resourceTypes:    []string{"bucket"},
+			expectedSegments: map[string]string{"name": "orders", "type": "bucket"},
+		},
+		{
+			name:             "environment and region",
+			namer:            namer.New("my-prod-stack", namer.WithEnvironment("production"), namer.WithRegion("us-central1")),
+			input:            "my-prod-stack-prd-usc1-orders-bucket",
+			resourceTypes:    []string{"bucket"},
+			expectedSegments: map[string]string{"base": "my-prod-stack", "env": "prd", "region": "usc1", "name": "orders", "type": "bucket"},
+		},
+		{
+			name:             "resource type hint settles the boundary",
+			namer:            namer.New("my-prod-stack"),
+			input:            "my-prod-stack-pending-work-queue",
+			resourceTypes:    []string{"bucket", "queue"},
+			expectedSegments: map[string]string{"base": "my-prod-stack", "name": "pending-work", "type": "queue"},
+		},
+		{
+			name:              "unknown boundary is ambiguous",
+			namer:             namer.New("my-prod-stack"),
+			input:             "my-prod-stack-backend-processor-service-account",
+			expectedSegments:  map[string]string{"base": "my-prod-stack", "name": "backend-processor-service", "type": "account"},
+			expectedAmbiguous: []string{"name", "type"},
+		},
+		{
+			name:              "truncated base name",
+			namer:             namer.New("cloudflare-edge-waf"),
+			input:             "cloudf-l7-ruleset-ddos-managed",
+			resourceTypes:     []string{"managed"},
+			expectedSegments:  map[string]string{"base": "cloudf", "name": "l7-ruleset-ddos", "type": "managed"},
+			expectedTruncated: true,
+			expectedAmbiguous: []string{"base", "name", "type"},
+		},
+		{
+			name: "truncated base name in the middle of a template",
+			namer: namer.New("my-prod-stack",
+				namer.WithTemplate(namer.MustParseTemplate("{type}-{base}-{name}")),
+			),
+			input:             "st-my-pr-orders",
+			expectedSegments:  map[string]string{"base": "my-pr", "name": "orders", "type": "st"},
+			expectedTruncated: true,
+			expectedAmbiguous: []string{"base", "name", "type"},
+		},
+		{
+			name:             "underscore separator",
+			namer:            namer.New("stack", namer.WithSeparator("_")),
+			input:            "stack_user-auth_service-account",
+			resourceTypes:    []string{"service-account"},
+			expectedSegments: map[string]string{"base": "stack", "name": "user-auth", "type": "service-account"},
+		},
+		{
+			name: "template with literal and trailing base name",
+			namer: namer.New("stack",
+				namer.WithTemplate(namer.MustParseTemplate("{type}-k8s-{name}-{env}-{base?}")),
+				namer.WithEnvironment("dev"),
+			),
+			input:            "st-k8s-order-api-dev-stack",
+			expectedSegments: map[string]string{"base": "stack", "env": "dev", "name": "order-api", "type": "st"},
+		},
+		{
+			name: "template values",
+			namer: namer.New("stack",
+				namer.WithTemplate(namer.MustParseTemplate("{org}-{name}-{type}")),
+				namer.WithValue("org", "acme"),
+			),
+			input:            "acme-orders-bucket",
+			expectedSegments: map[string]string{"org": "acme", "name": "orders", "type": "bucket"},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			parsed, err := testCase.namer.Parse(testCase.input, testCase.resourceTypes...)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			for key, expected := range testCase.expectedSegments {
+				if parsed.Segments[key] != expected {
+					t.Errorf("Parse() segment %s = %q, want %q", key, parsed.Segments[key], expected)
+				}
+			}
+
+			if parsed.Base != testCase.expectedSegments["base"] ||
+				parsed.Name != testCase.expectedSegments["name"] ||
+				parsed.Type != testCase.expectedSegments["type"] {
+				t.Errorf("Parse() = %q %q %q, want %q %q %q", parsed.Base, parsed.Name, parsed.Type,
+					testCase.expectedSegments["base"], testCase.expectedSegments["name"], testCase.expectedSegments["type"])
+			}
+
+			if parsed.Truncated != testCase.expectedTruncated {
+				t.Errorf("Parse() truncated = %v, want %v", parsed.Truncated, testCase.expectedTruncated)
+			}
+
+			if !slices.Equal(parsed.Ambiguous, testCase.expectedAmbiguous) {
+				t.Errorf("Parse() ambiguous = %v, want %v", parsed.Ambiguous, testCase.expectedAmbiguous)
+			}
+		})
+	}
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	n := namer.New("my-prod-stack", namer.WithEnvironment("staging"), namer.WithRegion("eastus2"))
+	name := n.NewResourceName("orders", "bucket", 63)
+
+	parsed, err := n.Parse(name, "bucket")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if parsed.Name != "orders" || parsed.Type != "bucket" || len(parsed.Ambiguous) > 0 {
+		t.Errorf("Parse(%q) = %+v, want name orders and type bucket", name, parsed)
+	}
+}
+
+func TestParse_TruncatedWithoutBaseName(t *testing.T) {
+	t.Parallel()
+
+	n := namer.New("")
+	name := n.NewResourceName("orders", "bucket", 12)
+
+	parsed, err := n.Parse(name, "bucke")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	// without a base name, truncation can't be detected
+	if parsed.Truncated || parsed.Name != "order" || parsed.Type != "bucke" {
+		t.Errorf("Parse(%q) = %+v, want name order, type bucke and not truncated", name, parsed)
+	}
+}
+
+func TestParse_NotMatching(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		namer namer.Namer
+		input string
+	}{
+		{
+			name:  "different base name",
+			namer: namer.New("my-prod-stack"),
+			input: "other-orders-bucket",
+		},
+		{
+			name:  "missing environment",
+			namer: namer.New("stack", namer.WithEnvironment("production")),
+			input: "stack-orders-bucket",
+		},
+		{
+			name:  "missing resource name",
+			namer: namer.New("stack"),
+			input: "stack",
+		},
+		{
+			name:  "missing literal",
+			namer: namer.New("stack", namer.WithTemplate(namer.MustParseTemplate("{base}-k8s-{name}"))),
+			input: "stack-orders",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if parsed, err := testCase.namer.Parse(testCase.input); err == nil {
+				t.Errorf("Parse() = %+v, want an error", parsed)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package namer
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Well-known profile names.
+const (
+	// ProfileGCP follows RFC 1035, as most GCP resources do.
+	ProfileGCP = "gcp"
+	// ProfileKubernetes follows RFC 1123 labels, as most Kubernetes objects do.
+	ProfileKubernetes = "kubernetes"
+	// ProfileAWSS3 follows the S3 bucket naming rules.
+	ProfileAWSS3 = "aws-s3"
+	// ProfileAzureStorage follows the Azure storage account naming rules.
+	ProfileAzureStorage = "azure-storage"
+)
+
+// Profile is a set of naming rules of a cloud provider or resource kind.
+type Profile struct {
+	// Name identifies the profile. E.g.: gcp.
+	Name string
+	// MinLength is the min length of names.
+	MinLength int
+	// MaxLength is the max length of names.
+	MaxLength int
+	// LeadingDigit allows names to start with a digit.
+	LeadingDigit bool
+	// Punctuation holds the interior characters allowed besides lowercase
+	// letters and digits. E.g.: "-.".
+	Punctuation string
+	// Separator is the separator set by WithProfile.
+	Separator string
+	// NoAdjacentPeriods rejects names with two periods in a row.
+	NoAdjacentPeriods bool
+	// NoIPAddress rejects names formatted as an IP address. E.g.: 192.168.5.4.
+	NoIPAddress bool
+	// ReservedPrefixes are the prefixes names must not start with. E.g.: xn--.
+	ReservedPrefixes []string
+	// ReservedSuffixes are the suffixes names must not end with. E.g.: -s3alias.
+	ReservedSuffixes []string
+}
+
+// profiles holds the well-known profiles.
+// See:
+// - https://cloud.google.com/compute/docs/naming-resources
+// - https://kubernetes.io/docs/concepts/overview/working-with-objects/names/
+// - https://docs.aws.amazon.com/AmazonS3/latest/userguide/bucketnamingrules.html
+// - https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules
+var profiles = map[string]Profile{
+	ProfileGCP:        {Name: ProfileGCP, MinLength: 1, MaxLength: 63, Punctuation: "-", Separator: "-"},
+	ProfileKubernetes: {Name: ProfileKubernetes, MinLength: 1, MaxLength: 63, LeadingDigit: true, Punctuation: "-", Separator: "-"},
+	ProfileAWSS3: {
+		Name: ProfileAWSS3, MinLength: 3, MaxLength: 63, LeadingDigit: true, Punctuation: "-.", Separator: "-",
+		NoAdjacentPeriods: true,
+		NoIPAddress:       true,
+		ReservedPrefixes:  []string{"xn--", "sthree-", "amzn-s3-demo-"},
+		ReservedSuffixes:  []string{"-s3alias", "--ol-s3", ".mrap", "--x-s3", "--table-s3"},
+	},
+	ProfileAzureStorage: {Name: ProfileAzureStorage, MinLength: 3, MaxLength: 24, LeadingDigit: true, Separator: ""},
+}
+
+// LookupProfile returns the well-known profile with the given name.
+func LookupProfile(name string) (Profile, error) {
+	profile, ok := profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("unknown profile %q, expected one of %s", name, strings.Join(ProfileNames(), ", "))
+	}
+	profile.ReservedPrefixes = slices.Clone(profile.ReservedPrefixes)
+	profile.ReservedSuffixes = slices.Clone(profile.ReservedSuffixes)
+
+	return profile, nil
+}
+
+// ProfileNames returns the names of the well-known profiles in order.
+func ProfileNames() []string {
+	return sortedKeys(profiles)
+}
+
+// WithProfile validates names with the rules of the profile instead of RFC
+// 1035 and sets its separator. Set WithSeparator after it to override the
+// separator.
+func WithProfile(profile Profile) Option {
+	return func(n *Namer) {
+		n.profile = &profile
+		n.separator = profile.Separator
+	}
+}
+
+// Validate checks the name against every rule of the profile. All violations
+// are returned at once.
+func (p Profile) Validate(name string) error {
+	if name == "" {
+		return errors.New("name must not be empty")
+	}
+
+	var errs []error
+	if len(name) < p.MinLength {
+		errs = append(errs, fmt.Errorf("name must be at least %d characters", p.MinLength))
+	}
+
+	if len(name) > p.MaxLength {
+		errs = append(errs, fmt.Errorf("name must be at most %d characters", p.MaxLength))
+	}
+
+	if first := rune(name[0]); !isLowerLetter(first) && (!p.LeadingDigit || !isDigit(first)) {
+		if p.LeadingDigit {
+			errs = append(errs, errors.New("name must start with a lowercase letter or digit"))
+		} else {
+			errs = append(errs, errors.New("name must start with a lowercase letter"))
+		}
+	}
+
+	if last := rune(name[len(name)-1]); !isLowerLetter(last) && !isDigit(last) {
+		errs = append(errs, errors.New("name must end with a lowercase letter or digit"))
+	}
+
+	// the ends are checked above
+	var notAllowed []rune
+	for _, r := range name[1:max(len(name)-1, 1)] {
+		if !isLowerLetter(r) && !isDigit(r) && !strings.ContainsRune(p.Punctuation, r) && !slices.Contains(notAllowed, r) {
+			notAllowed = append(notAllowed, r)
+		}
+	}
+	if len(notAllowed) > 0 {
+		errs = append(errs, fmt.Errorf("name must only contain %s, found %q",
+			describeCharacters(p.Punctuation), string(notAllowed)))
+	}
+
+	if p.NoAdjacentPeriods && strings.Contains(name, "..") {
+		errs = append(errs, errors.New("name must not contain two adjacent periods"))
+	}
+
+	if p.NoIPAddress && isIPv4Address(name) {
+		errs = append(errs, errors.New("name must not be formatted as an IP address"))
+	}
+
+	for _, prefix := range p.ReservedPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			errs = append(errs, fmt.Errorf("name must not start with the reserved prefix %q", prefix))
+		}
+	}
+
+	for _, suffix := range p.ReservedSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			errs = append(errs, fmt.Errorf("name must not end with the reserved suffix %q", suffix))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// profileRules returns the profile set with WithProfile. By default, names follow
+// RFC 1035 allowing the separator as an interior character.
+func (e Namer) profileRules() Profile {
+	if e.profile != nil {
+		return *e.profile
+	}
+
+	profile := profiles[ProfileGCP]
+	if !strings.Contains(profile.Punctuation, e.separator) {
+		profile.Punctuation += e.separator
+	}
+
+	return profile
+}
+
+// describeCharacters describes the characters allowed in messages.
+func describeCharacters(punctuation string) string {
+	if punctuation == "" {
+		return "lowercase letters and digits"
+	}
+
+	return fmt.Sprintf("lowercase letters, digits and %q", punctuation)
+}
+
+// isIPv4Address reports whether the name is formatted as an IPv4 address, as
+// four groups of digits separated by periods.
+func isIPv4Address(name string) bool {
+	groups := strings.Split(name, ".")
+	if len(groups) != 4 {
+		return false
+	}
+
+	for _, group := range groups {
+		if group == "" || strings.ContainsFunc(group, func(r rune) bool { return !isDigit(r) }) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isLowerLetter reports whether r is an ASCII lowercase letter.
+func isLowerLetter(r rune) bool {
+	return r >= 'a' && r <= 'z'
+}
+
+// isDigit reports whether r is an ASCII digit.
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
@@ -0,0 +1,168 @@
+package namer_test
+
+import (
+	"strings"
+	"testing"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+)
+
+func TestProfile_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		profile        string
+		value          string
+		expectedErrors []string
+	}{
+		{
+			name:    "valid gcp name",
+			profile: namer.ProfileGCP,
+			value:   "my-prod-stack-orders-bucket",
+		},
+		{
+			name:    "gcp name starting with a digit",
+			profile: namer.ProfileGCP,
+			value:   "1st-bucket",
+			expectedErrors: []string{
+				"name must start with a lowercase letter",
+			},
+		},
+		{
+			name:    "kubernetes name starting with a digit",
+			profile: namer.ProfileKubernetes,
+			value:   "1st-worker",
+		},
+		{
+			name:    "s3 bucket with periods",
+			profile: namer.ProfileAWSS3,
+			value:   "assets.example.com",
+		},
+		{
+			name:    "s3 bucket with adjacent periods",
+			profile: namer.ProfileAWSS3,
+			value:   "my..bucket",
+			expectedErrors: []string{
+				"name must not contain two adjacent periods",
+			},
+		},
+		{
+			name:    "s3 bucket formatted as an IP address",
+			profile: namer.ProfileAWSS3,
+			value:   "192.168.5.4",
+			expectedErrors: []string{
+				"name must not be formatted as an IP address",
+			},
+		},
+		{
+			name:    "s3 bucket with a reserved prefix",
+			profile: namer.ProfileAWSS3,
+			value:   "xn--bucket",
+			expectedErrors: []string{
+				`name must not start with the reserved prefix "xn--"`,
+			},
+		},
+		{
+			name:    "s3 bucket with the sthree prefix",
+			profile: namer.ProfileAWSS3,
+			value:   "sthree-bucket",
+			expectedErrors: []string{
+				`name must not start with the reserved prefix "sthree-"`,
+			},
+		},
+		{
+			name:    "s3 bucket with a reserved suffix",
+			profile: namer.ProfileAWSS3,
+			value:   "bucket-s3alias",
+			expectedErrors: []string{
+				`name must not end with the reserved suffix "-s3alias"`,
+			},
+		},
+		{
+			name:    "reserved prefixes only apply to s3",
+			profile: namer.ProfileGCP,
+			value:   "sthree-bucket",
+		},
+		{
+			name:    "every violation is reported",
+			profile: namer.ProfileAzureStorage,
+			value:   "-My_Storage-Account-Backup-",
+			expectedErrors: []string{
+				"name must be at most 24 characters",
+				"name must start with a lowercase letter or digit",
+				"name must end with a lowercase letter or digit",
+				`name must only contain lowercase letters and digits, found "M_S-AB"`,
+			},
+		},
+		{
+			name:    "too short",
+			profile: namer.ProfileAWSS3,
+			value:   "ab",
+			expectedErrors: []string{
+				"name must be at least 3 characters",
+			},
+		},
+		{
+			name:    "empty",
+			profile: namer.ProfileGCP,
+			value:   "",
+			expectedErrors: []string{
+				"name must not be empty",
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			profile, err := namer.LookupProfile(testCase.profile)
+			if err != nil {
+				t.Fatalf("LookupProfile() error = %v", err)
+			}
+
+			err = profile.Validate(testCase.value)
+			if len(testCase.expectedErrors) == 0 {
+				if err != nil {
+					t.Errorf("Validate(%q) error = %v, want no error", testCase.value, err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("Validate(%q) expected an error", testCase.value)
+			}
+
+			if err.Error() != strings.Join(testCase.expectedErrors, "\n") {
+				t.Errorf("Validate(%q) error = %v, want %v", testCase.value, err, testCase.expectedErrors)
+			}
+		})
+	}
+}
+
+func TestNewResourceName_WithProfile(t *testing.T) {
+	t.Parallel()
+
+	profile, err := namer.LookupProfile(namer.ProfileAzureStorage)
+	if err != nil {
+		t.Fatalf("LookupProfile() error = %v", err)
+	}
+
+	n := namer.New("enterprise", namer.WithProfile(profile))
+	result := n.NewResourceName("backup", "storage", profile.MaxLength)
+
+	if expected := "enterprisebackupstorage"; result != expected {
+		t.Errorf("NewResourceName() = %v, want %v", result, expected)
+	}
+}
+
+func TestLookupProfile_Unknown(t *testing.T) {
+	t.Parallel()
+
+	_, err := namer.LookupProfile("gcs")
+	if err == nil || !strings.Contains(err.Error(), "aws-s3, azure-storage, gcp, kubernetes") {
+		t.Errorf("LookupProfile() error = %v, want the known profiles", err)
+	}
+}
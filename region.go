@@ -0,0 +1,205 @@
+package namer
+
+import (
+	"maps"
+	"strings"
+)
+
+// regionCodes maps cloud regions to compact codes. Codes are unique across
+// clouds, so they can be reversed with RegionName.
+//
+// GCP codes join the geography, the direction and the number. E.g.:
+// us-central1→usc1, europe-west4→euw4, northamerica-northeast1→nane1.
+// AWS codes use a one letter geography except for Asia Pacific. E.g.:
+// us-east-1→ue1, eu-west-1→ew1, ap-southeast-2→apse2.
+// Azure codes abbreviate the region without a number unless it has one. E.g.:
+// eastus2→eus2, westeurope→weu, australiasoutheast→ause.
+var regionCodes = map[string]string{
+	// GCP
+	"africa-south1":           "afs1",
+	"asia-east1":              "ase1",
+	"asia-east2":              "ase2",
+	"asia-northeast1":         "asne1",
+	"asia-northeast2":         "asne2",
+	"asia-northeast3":         "asne3",
+	"asia-south1":             "ass1",
+	"asia-south2":             "ass2",
+	"asia-southeast1":         "asse1",
+	"asia-southeast2":         "asse2",
+	"australia-southeast1":    "ause1",
+	"australia-southeast2":    "ause2",
+	"europe-central2":         "euc2",
+	"europe-north1":           "eun1",
+	"europe-north2":           "eun2",
+	"europe-southwest1":       "eusw1",
+	"europe-west1":            "euw1",
+	"europe-west2":            "euw2",
+	"europe-west3":            "euw3",
+	"europe-west4":            "euw4",
+	"europe-west6":            "euw6",
+	"europe-west8":            "euw8",
+	"europe-west9":            "euw9",
+	"europe-west10":           "euw10",
+	"europe-west12":           "euw12",
+	"me-central1":             "mec1",
+	"me-central2":             "mec2",
+	"me-west1":                "mew1",
+	"northamerica-northeast1": "nane1",
+	"northamerica-northeast2": "nane2",
+	"northamerica-south1":     "nas1",
+	"southamerica-east1":      "sae1",
+	"southamerica-west1":      "saw1",
+	"us-central1":             "usc1",
+	"us-east1":                "use1",
+	"us-east4":                "use4",
+	"us-east5":                "use5",
+	"us-south1":               "uss1",
+	"us-west1":                "usw1",
+	"us-west2":                "usw2",
+	"us-west3":                "usw3",
+	"us-west4":                "usw4",
+
+	// AWS
+	"af-south-1":     "fs1",
+	"ap-east-1":      "ape1",
+	"ap-east-2":      "ape2",
+	"ap-northeast-1": "apne1",
+	"ap-northeast-2": "apne2",
+	"ap-northeast-3": "apne3",
+	"ap-south-1":     "aps1",
+	"ap-south-2":     "aps2",
+	"ap-southeast-1": "apse1",
+	"ap-southeast-2": "apse2",
+	"ap-southeast-3": "apse3",
+	"ap-southeast-4": "apse4",
+	"ap-southeast-5": "apse5",
+	"ap-southeast-6": "apse6",
+	"ap-southeast-7": "apse7",
+	"ca-central-1":   "cc1",
+	"ca-west-1":      "cw1",
+	"eu-central-1":   "ec1",
+	"eu-central-2":   "ec2",
+	"eu-north-1":     "en1",
+	"eu-south-1":     "es1",
+	"eu-south-2":     "es2",
+	"eu-west-1":      "ew1",
+	"eu-west-2":      "ew2",
+	"eu-west-3":      "ew3",
+	"il-central-1":   "ic1",
+	"me-central-1":   "mc1",
+	"me-south-1":     "ms1",
+	"mx-central-1":   "xc1",
+	"sa-east-1":      "se1",
+	"us-east-1":      "ue1",
+	"us-east-2":      "ue2",
+	"us-gov-east-1":  "uge1",
+	"us-gov-west-1":  "ugw1",
+	"us-west-1":      "uw1",
+	"us-west-2":      "uw2",
+
+	// Azure
+	"australiacentral":   "auc",
+	"australiacentral2":  "auc2",
+	"australiaeast":      "aue",
+	"australiasoutheast": "ause",
+	"brazilsouth":        "brs",
+	"brazilsoutheast":    "brse",
+	"canadacentral":      "cac",
+	"canadaeast":         "cae",
+	"centralindia":       "inc",
+	"centralus":          "cus",
+	"chilecentral":       "clc",
+	"eastasia":           "ea",
+	"eastus":             "eus",
+	"eastus2":            "eus2",
+	"francecentral":      "frc",
+	"francesouth":        "frs",
+	"germanynorth":       "gn",
+	"germanywestcentral": "gwc",
+	"indonesiacentral":   "idc",
+	"israelcentral":      "ilc",
+	"italynorth":         "itn",
+	"japaneast":          "jpe",
+	"japanwest":          "jpw",
+	"koreacentral":       "krc",
+	"koreasouth":         "krs",
+	"malaysiawest":       "myw",
+	"mexicocentral":      "mxc",
+	"newzealandnorth":    "nzn",
+	"northcentralus":     "ncus",
+	"northeurope":        "neu",
+	"norwayeast":         "nwe",
+	"norwaywest":         "nww",
+	"polandcentral":      "plc",
+	"qatarcentral":       "qac",
+	"southafricanorth":   "san",
+	"southafricawest":    "saw",
+	"southcentralus":     "scus",
+	"southeastasia":      "sea",
+	"southindia":         "ins",
+	"spaincentral":       "spc",
+	"swedencentral":      "sdc",
+	"switzerlandnorth":   "szn",
+	"switzerlandwest":    "szw",
+	"uaecentral":         "uaec",
+	"uaenorth":           "uaen",
+	"uksouth":            "uks",
+	"ukwest":             "ukw",
+	"westcentralus":      "wcus",
+	"westeurope":         "weu",
+	"westindia":          "inw",
+	"westus":             "wus",
+	"westus2":            "wus2",
+	"westus3":            "wus3",
+}
+
+// regionNames is the reverse of regionCodes.
+var regionNames = reverseRegionCodes()
+
+// RegionCode returns the compact code of a GCP, AWS or Azure region.
+// E.g.: us-central1→usc1, eastus2→eus2.
+func RegionCode(region string) (string, bool) {
+	code, ok := regionCodes[strings.ToLower(strings.TrimSpace(region))]
+
+	return code, ok
+}
+
+// RegionName returns the region of a code returned by RegionCode.
+func RegionName(code string) (string, bool) {
+	region, ok := regionNames[strings.ToLower(strings.TrimSpace(code))]
+
+	return region, ok
+}
+
+// RegionCodes returns a copy of the built-in region code table.
+func RegionCodes() map[string]string {
+	return maps.Clone(regionCodes)
+}
+
+// WithRegion adds the region code to every name, after the environment or
+// wherever a template sets {region}. Regions not in the built-in table are
+// added in lowercase as is. The region is never truncated.
+func WithRegion(region string) Option {
+	return func(n *Namer) {
+		code, ok := RegionCode(region)
+		if !ok {
+			code = strings.ToLower(strings.TrimSpace(region))
+		}
+		n.region = code
+	}
+}
+
+// regionSegment returns the region code as a fixed segment.
+func (e Namer) regionSegment() Segment {
+	return Segment{Name: SegmentRegion, Value: e.region, Fixed: true}
+}
+
+// reverseRegionCodes maps region codes back to their regions.
+func reverseRegionCodes() map[string]string {
+	names := make(map[string]string, len(regionCodes))
+	for region, code := range regionCodes {
+		names[code] = region
+	}
+
+	return names
+}
@@ -0,0 +1,149 @@
+package namer_test
+
+import (
+	"regexp"
+	"testing"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+)
+
+func TestRegionCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		region   string
+		expected string
+	}{
+		{region: "us-central1", expected: "usc1"},
+		{region: "northamerica-northeast1", expected: "nane1"},
+		{region: "europe-west10", expected: "euw10"},
+		{region: "us-east-1", expected: "ue1"},
+		{region: "ap-southeast-2", expected: "apse2"},
+		{region: "eastus2", expected: "eus2"},
+		{region: "australiasoutheast", expected: "ause"},
+		{region: "WestEurope", expected: "weu"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.region, func(t *testing.T) {
+			t.Parallel()
+
+			code, ok := namer.RegionCode(testCase.region)
+			if !ok || code != testCase.expected {
+				t.Errorf("RegionCode() = %v, %v, want %v, true", code, ok, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestRegionCode_Unknown(t *testing.T) {
+	t.Parallel()
+
+	if code, ok := namer.RegionCode("mars-north1"); ok {
+		t.Errorf("RegionCode() = %v, want unknown region", code)
+	}
+
+	if region, ok := namer.RegionName("zz9"); ok {
+		t.Errorf("RegionName() = %v, want unknown code", region)
+	}
+}
+
+func TestRegionCodes_Reversible(t *testing.T) {
+	t.Parallel()
+
+	codePattern := regexp.MustCompile("^[a-z][a-z0-9]*$")
+	seen := make(map[string]string)
+
+	for region, code := range namer.RegionCodes() {
+		if other, ok := seen[code]; ok {
+			t.Errorf("code %q is shared by %q and %q", code, region, other)
+		}
+		seen[code] = region
+
+		if !codePattern.MatchString(code) {
+			t.Errorf("code %q of %q is not a valid name component", code, region)
+		}
+
+		if len(code) >= len(region) {
+			t.Errorf("code %q is not shorter than %q", code, region)
+		}
+
+		if reversed, ok := namer.RegionName(code); !ok || reversed != region {
+			t.Errorf("RegionName(%q) = %v, want %v", code, reversed, region)
+		}
+	}
+}
+
+func TestNewResourceName_WithRegion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		opts         []namer.Option
+		serviceName  string
+		resourceType string
+		maxLength    int
+		expected     string
+	}{
+		{
+			name:         "region code after base name",
+			opts:         []namer.Option{namer.WithRegion("us-central1")},
+			serviceName:  "orders",
+			resourceType: "bucket",
+			maxLength:    63,
+			expected:     "my-prod-stack-usc1-orders-bucket",
+		},
+		{
+			name:         "region code after environment",
+			opts:         []namer.Option{namer.WithRegion("northamerica-northeast1"), namer.WithEnvironment("production")},
+			serviceName:  "orders",
+			resourceType: "bucket",
+			maxLength:    63,
+			expected:     "my-prod-stack-prd-nane1-orders-bucket",
+		},
+		{
+			name:         "region code is not truncated",
+			opts:         []namer.Option{namer.WithRegion("northamerica-northeast1"), namer.WithEnvironment("production")},
+			serviceName:  "backend-processor",
+			resourceType: "service-account",
+			maxLength:    30,
+			expected:     "my-pr-prd-nane1-backend-servic",
+		},
+		{
+			name:         "unknown region as is",
+			opts:         []namer.Option{namer.WithRegion("On-Prem1")},
+			serviceName:  "orders",
+			resourceType: "bucket",
+			maxLength:    63,
+			expected:     "my-prod-stack-on-prem1-orders-bucket",
+		},
+		{
+			name: "region placed by template",
+			opts: []namer.Option{
+				namer.WithRegion("eastus2"),
+				namer.WithTemplate(namer.MustParseTemplate("{type}-{name}-{region}")),
+			},
+			serviceName:  "orders",
+			resourceType: "st",
+			maxLength:    24,
+			expected:     "st-orders-eus2",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			n := namer.New("my-prod-stack", testCase.opts...)
+			result := n.NewResourceName(testCase.serviceName, testCase.resourceType, testCase.maxLength)
+
+			if result != testCase.expected {
+				t.Errorf("NewResourceName() = %v, want %v", result, testCase.expected)
+			}
+
+			if len(result) > testCase.maxLength {
+				t.Errorf("NewResourceName() length = %d, want <= %d", len(result), testCase.maxLength)
+			}
+		})
+	}
+}
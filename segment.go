@@ -0,0 +1,62 @@
+package namer
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Well-known segment names.
+const (
+	// SegmentBase is the base name set on the Namer.
+	SegmentBase = "base"
+	// SegmentName is the resource name.
+	SegmentName = "name"
+	// SegmentType is the resource type or group.
+	SegmentType = "type"
+	// SegmentEnvironment is the environment set with WithEnvironment.
+	SegmentEnvironment = "env"
+	// SegmentRegion is the region code set with WithRegion.
+	SegmentRegion = "region"
+	// SegmentSuffix is the suffix set with WithRandomSuffix or WithKeySuffix.
+	SegmentSuffix = "suffix"
+	// SegmentIndex is the index of names generated with NewResourceNames.
+	SegmentIndex = "index"
+)
+
+// basePriority makes the base name the first segment to be truncated.
+const basePriority = 1
+
+// Segment is a named part of a resource name. E.g.: org, env, region, service.
+type Segment struct {
+	// Name identifies the segment. It is not part of the generated name.
+	Name string
+	// Value is the text of the segment. Empty values are left out of the name.
+	Value string
+	// Priority sets the truncation order. Segments with a higher priority are
+	// truncated first. The base name has priority 1 and segments default to 0,
+	// so they are all truncated together when the base alone is too short.
+	Priority int
+	// Fixed segments are never truncated. E.g.: literal tokens in a template.
+	Fixed bool
+}
+
+// segmentValues returns the values of the segments in order.
+func segmentValues(segments []Segment) []string {
+	values := make([]string, len(segments))
+	for i, segment := range segments {
+		values[i] = segment.Value
+	}
+
+	return values
+}
+
+// sortedKeys returns the keys of the map in order.
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, cmp.Compare[string])
+
+	return keys
+}
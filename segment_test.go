@@ -0,0 +1,144 @@
+package namer_test
+
+import (
+	"testing"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+)
+
+func TestNewName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		baseName  string
+		segments  []namer.Segment
+		maxLength int
+		expected  string
+	}{
+		{
+			name:     "org env region service component type",
+			baseName: "acme",
+			segments: []namer.Segment{
+				{Name: "env", Value: "prd"},
+				{Name: "region", Value: "usc1"},
+				{Name: "service", Value: "orders"},
+				{Name: "component", Value: "api"},
+				{Name: "type", Value: "bucket"},
+			},
+			maxLength: 63,
+			expected:  "acme-prd-usc1-orders-api-bucket",
+		},
+		{
+			name:     "empty segments are skipped",
+			baseName: "acme",
+			segments: []namer.Segment{
+				{Name: "env", Value: ""},
+				{Name: "service", Value: "orders"},
+				{Name: "type", Value: ""},
+			},
+			maxLength: 63,
+			expected:  "acme-orders",
+		},
+		{
+			name:     "proportional truncation across all segments",
+			baseName: "acme",
+			segments: []namer.Segment{
+				{Name: "env", Value: "production"},
+				{Name: "region", Value: "us-central1"},
+				{Name: "service", Value: "orders-processor"},
+				{Name: "type", Value: "service-account"},
+			},
+			maxLength: 30,
+			expected:  "ac-produ-us-ce-orders-service",
+		},
+		{
+			name:     "higher priority segment is truncated first",
+			baseName: "acme",
+			segments: []namer.Segment{
+				{Name: "service", Value: "orders"},
+				{Name: "component", Value: "ingestion-pipeline", Priority: 2},
+				{Name: "type", Value: "bucket"},
+			},
+			maxLength: 30,
+			expected:  "acme-orders-ingestion-p-bucket",
+		},
+		{
+			name:     "segment sharing the base name priority",
+			baseName: "platform",
+			segments: []namer.Segment{
+				{Name: "region", Value: "northamerica-northeast1", Priority: 1},
+				{Name: "service", Value: "db"},
+			},
+			maxLength: 25,
+			expected:  "platfo-northamerica-no-db",
+		},
+		{
+			name:     "many short segments",
+			baseName: "org",
+			segments: []namer.Segment{
+				{Name: "a", Value: "alpha"},
+				{Name: "b", Value: "bravo"},
+				{Name: "c", Value: "charlie"},
+				{Name: "d", Value: "delta"},
+				{Name: "e", Value: "echo"},
+				{Name: "f", Value: "foxtrot"},
+			},
+			maxLength: 20,
+			expected:  "o-al-br-cha-de-e-fox",
+		},
+		{
+			name:     "separators overflowing the proportional budget",
+			baseName: "datacenter",
+			segments: []namer.Segment{
+				{Name: "env", Value: "production"},
+				{Name: "region", Value: "europewest"},
+				{Name: "service", Value: "processor"},
+				{Name: "type", Value: "serviceacc"},
+			},
+			maxLength: 27,
+			expected:  "data-produ-europ-proc-servi",
+		},
+		{
+			name:     "no base name",
+			baseName: "",
+			segments: []namer.Segment{
+				{Name: "service", Value: "orders"},
+				{Name: "type", Value: "queue"},
+			},
+			maxLength: 63,
+			expected:  "orders-queue",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			n := namer.New(testCase.baseName)
+			result := n.NewName(testCase.maxLength, testCase.segments...)
+
+			if result != testCase.expected {
+				t.Errorf("NewName() = %v, want %v", result, testCase.expected)
+			}
+
+			if len(result) > testCase.maxLength {
+				t.Errorf("NewName() length = %d, want <= %d", len(result), testCase.maxLength)
+			}
+		})
+	}
+}
+
+func TestNewName_MatchesNewResourceName(t *testing.T) {
+	t.Parallel()
+
+	n := namer.New("my-prod-stack")
+	expected := n.NewResourceName("backend-processor", "service-account", 30)
+	result := n.NewName(30,
+		namer.Segment{Name: namer.SegmentName, Value: "backend-processor"},
+		namer.Segment{Name: namer.SegmentType, Value: "service-account"},
+	)
+
+	if result != expected {
+		t.Errorf("NewName() = %v, want %v", result, expected)
+	}
+}
@@ -0,0 +1,95 @@
+package namer
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	mathrand "math/rand/v2"
+)
+
+// suffixAlphabet holds the characters of generated suffixes. Lowercase letters
+// and digits are valid in every name, including Azure storage accounts.
+const suffixAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// WithRandomSuffix appends a random alphanumeric suffix of the given length to
+// every name, e.g. for globally unique S3 buckets, GCS buckets and Azure
+// storage accounts. Space for the suffix is reserved in the length budget and
+// it is never truncated. Templates may place it with {suffix}; otherwise it is
+// appended at the end. The suffix is crypto-random unless set otherwise with
+// WithRandomReader.
+func WithRandomSuffix(length int) Option {
+	return func(n *Namer) {
+		n.suffixLength = length
+		n.suffixKey = nil
+	}
+}
+
+// WithKeySuffix appends a suffix of the given length derived from a key the
+// caller controls, e.g. a project, account or stack ID. The same key and
+// segments always yield the same name, and different keys yield different
+// names, so a stack deployed to several accounts gets distinct yet
+// reproducible global names. Like WithRandomSuffix, it is never truncated.
+func WithKeySuffix(key string, length int) Option {
+	return func(n *Namer) {
+		n.suffixLength = length
+		n.suffixKey = &key
+	}
+}
+
+// WithRandomReader sets the source of random suffixes. E.g.: a seeded
+// math/rand/v2 ChaCha8 to get deterministic names in tests.
+func WithRandomReader(reader io.Reader) Option {
+	return func(n *Namer) {
+		n.random = reader
+	}
+}
+
+// suffixSegments returns the suffix as a fixed segment, if set. Keyed
+// suffixes are derived from the key and the untruncated segments.
+func (e Namer) suffixSegments(segments []Segment) ([]Segment, error) {
+	if e.suffixLength <= 0 {
+		return nil, nil
+	}
+
+	reader := e.random
+	if reader == nil {
+		reader = rand.Reader
+	}
+
+	if e.suffixKey != nil {
+		seed := sha256.Sum256([]byte(*e.suffixKey + "\x00" + e.join(segmentValues(segments)...)))
+		reader = mathrand.NewChaCha8(seed)
+	}
+
+	suffix, err := randomString(reader, e.suffixLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate random suffix: %w", err)
+	}
+
+	e.log().Debug("Generated name suffix", "suffix", suffix, "keyed", e.suffixKey != nil)
+
+	return []Segment{{Name: SegmentSuffix, Value: suffix, Fixed: true}}, nil
+}
+
+// randomString reads a string of the given length from the suffix alphabet.
+// Bytes beyond the largest multiple of the alphabet size are discarded, so
+// every character is equally likely.
+func randomString(reader io.Reader, length int) (string, error) {
+	limit := 256 - 256%len(suffixAlphabet)
+	result := make([]byte, 0, length)
+	buffer := make([]byte, length)
+
+	for len(result) < length {
+		if _, err := io.ReadFull(reader, buffer); err != nil {
+			return "", err
+		}
+		for _, b := range buffer {
+			if int(b) < limit && len(result) < length {
+				result = append(result, suffixAlphabet[int(b)%len(suffixAlphabet)])
+			}
+		}
+	}
+
+	return string(result), nil
+}
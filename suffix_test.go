@@ -0,0 +1,226 @@
+package namer_test
+
+import (
+	"errors"
+	"math/rand/v2"
+	"regexp"
+	"strings"
+	"testing"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+)
+
+func TestNewResourceName_WithRandomSuffix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		baseName     string
+		opts         []namer.Option
+		serviceName  string
+		resourceType string
+		maxLength    int
+		expected     string
+	}{
+		{
+			name:         "suffix appended",
+			baseName:     "my-prod-stack",
+			opts:         []namer.Option{namer.WithRandomSuffix(6)},
+			serviceName:  "orders",
+			resourceType: "bucket",
+			maxLength:    63,
+			expected:     "my-prod-stack-orders-bucket-b1s0bs",
+		},
+		{
+			name:         "suffix kept when truncating the base name",
+			baseName:     "cloudflare-edge-waf",
+			opts:         []namer.Option{namer.WithRandomSuffix(5)},
+			serviceName:  "zone",
+			resourceType: "dns",
+			maxLength:    21,
+			expected:     "cloudf-zone-dns-b1s0b",
+		},
+		{
+			name:         "suffix kept when truncating proportionally",
+			baseName:     "my-prod-stack",
+			opts:         []namer.Option{namer.WithRandomSuffix(5)},
+			serviceName:  "backend-processor",
+			resourceType: "service-account",
+			maxLength:    30,
+			expected:     "my-pro-backend-service-b1s0b",
+		},
+		{
+			name:         "azure storage account without separator",
+			baseName:     "enterprise",
+			opts:         []namer.Option{namer.WithRandomSuffix(6), namer.WithSeparator("")},
+			serviceName:  "backup",
+			resourceType: "storage",
+			maxLength:    24,
+			expected:     "enterbackupstorageb1s0bs",
+		},
+		{
+			name:     "suffix placed by template",
+			baseName: "my-prod-stack",
+			opts: []namer.Option{
+				namer.WithRandomSuffix(4),
+				namer.WithTemplate(namer.MustParseTemplate("{name}{suffix}-{type}")),
+			},
+			serviceName:  "orders",
+			resourceType: "bucket",
+			maxLength:    63,
+			expected:     "orders-b1s0-bucket",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var seed [32]byte
+			opts := append([]namer.Option{namer.WithRandomReader(rand.NewChaCha8(seed))}, testCase.opts...)
+
+			n := namer.New(testCase.baseName, opts...)
+			result := n.NewResourceName(testCase.serviceName, testCase.resourceType, testCase.maxLength)
+
+			if result != testCase.expected {
+				t.Errorf("NewResourceName() = %v, want %v", result, testCase.expected)
+			}
+
+			if len(result) > testCase.maxLength {
+				t.Errorf("NewResourceName() length = %d, want <= %d", len(result), testCase.maxLength)
+			}
+		})
+	}
+}
+
+func TestNewResourceName_WithCryptoRandomSuffix(t *testing.T) {
+	t.Parallel()
+
+	n := namer.New("my-prod-stack", namer.WithRandomSuffix(8))
+	suffixPattern := regexp.MustCompile("^my-prod-stack-orders-bucket-[a-z0-9]{8}$")
+
+	seen := make(map[string]bool)
+	for range 10 {
+		result := n.NewResourceName("orders", "bucket", 63)
+		if !suffixPattern.MatchString(result) {
+			t.Errorf("NewResourceName() = %v, want an 8 character alphanumeric suffix", result)
+		}
+		seen[result] = true
+	}
+
+	if len(seen) < 10 {
+		t.Errorf("NewResourceName() generated %d distinct names out of 10", len(seen))
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("no entropy")
+}
+
+func TestNewResourceName_WithRandomSuffixReaderError(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		r := recover()
+		if r == nil || !strings.Contains(r.(string), "no entropy") {
+			t.Errorf("Expected panic with the reader error, got %v", r)
+		}
+	}()
+
+	n := namer.New("stack", namer.WithRandomSuffix(6), namer.WithRandomReader(failingReader{}))
+	result := n.NewResourceName("orders", "bucket", 63)
+
+	t.Errorf("Expected panic but got result: %s", result)
+}
+
+func TestParse_WithRandomSuffix(t *testing.T) {
+	t.Parallel()
+
+	n := namer.New("my-prod-stack", namer.WithRandomSuffix(6))
+	name := n.NewResourceName("pending-work", "queue", 63)
+
+	parsed, err := n.Parse(name, "queue")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if parsed.Name != "pending-work" || parsed.Type != "queue" || len(parsed.Segments["suffix"]) != 6 {
+		t.Errorf("Parse(%q) = %+v, want name pending-work, type queue and a 6 character suffix", name, parsed)
+	}
+}
+
+func TestNewResourceName_WithKeySuffix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		key          string
+		otherKey     string
+		serviceName  string
+		resourceType string
+		maxLength    int
+	}{
+		{
+			name:         "distinct accounts",
+			key:          "123456789012",
+			otherKey:     "210987654321",
+			serviceName:  "orders",
+			resourceType: "bucket",
+			maxLength:    63,
+		},
+		{
+			name:         "distinct projects when truncating",
+			key:          "acme-prod",
+			otherKey:     "acme-staging",
+			serviceName:  "backend-processor",
+			resourceType: "service-account",
+			maxLength:    30,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := namer.New("my-prod-stack", namer.WithKeySuffix(testCase.key, 6)).
+				NewResourceName(testCase.serviceName, testCase.resourceType, testCase.maxLength)
+			again := namer.New("my-prod-stack", namer.WithKeySuffix(testCase.key, 6)).
+				NewResourceName(testCase.serviceName, testCase.resourceType, testCase.maxLength)
+			other := namer.New("my-prod-stack", namer.WithKeySuffix(testCase.otherKey, 6)).
+				NewResourceName(testCase.serviceName, testCase.resourceType, testCase.maxLength)
+
+			if result != again {
+				t.Errorf("NewResourceName() = %v and %v for the same key, want the same name", result, again)
+			}
+
+			if result == other {
+				t.Errorf("NewResourceName() = %v for distinct keys, want distinct names", result)
+			}
+
+			if len(result) > testCase.maxLength {
+				t.Errorf("NewResourceName() length = %d, want <= %d", len(result), testCase.maxLength)
+			}
+		})
+	}
+}
+
+func TestNewResourceName_WithKeySuffixPerResource(t *testing.T) {
+	t.Parallel()
+
+	n := namer.New("cloudflare-edge-waf", namer.WithKeySuffix("acme-prod", 5))
+
+	// truncation cuts what sets the names apart, the suffix keeps them distinct
+	first := n.NewResourceName("l7-ruleset-ddos-primary", "managed", 30)
+	second := n.NewResourceName("l7-ruleset-ddos-secondary", "managed", 30)
+
+	if first == second {
+		t.Errorf("NewResourceName() = %v for distinct resources, want distinct names", first)
+	}
+
+	expected := "cloudflare-edge-waf-orders-bucket-"
+	if result := n.NewResourceName("orders", "bucket", 63); !strings.HasPrefix(result, expected) || len(result) != len(expected)+5 {
+		t.Errorf("NewResourceName() = %v, want %v followed by a 5 character suffix", result, expected)
+	}
+}
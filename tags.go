@@ -0,0 +1,52 @@
+package namer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// AWS tag limits.
+// See: https://docs.aws.amazon.com/tag-editor/latest/userguide/tagging.html
+const (
+	// MaxTagKeyLength is the max length of tag keys.
+	MaxTagKeyLength = 128
+	// MaxTagValueLength is the max length of tag values.
+	MaxTagValueLength = 256
+	// MaxTags is the max number of user tags per resource.
+	MaxTags = 50
+)
+
+// reservedTagPrefix is the prefix of keys reserved for AWS.
+const reservedTagPrefix = "aws:"
+
+// NewTag normalizes a key and value into a valid AWS tag. Letters, digits,
+// spaces and + - = . _ : / @ are allowed; other characters are replaced with
+// hyphens. Keys over 128 characters and values over 256 characters are
+// truncated with a hash suffix. Keys can't be empty or start with "aws:".
+func NewTag(key, value string) (string, string, error) {
+	key = fitWithHash(strings.TrimSpace(replaceNotAllowed(key, isTagRune)), MaxTagKeyLength, strings.TrimSpace)
+	if key == "" {
+		return "", "", errors.New("tag key must not be empty")
+	}
+
+	if strings.HasPrefix(strings.ToLower(key), reservedTagPrefix) {
+		return "", "", fmt.Errorf("tag key %q must not start with %q", key, reservedTagPrefix)
+	}
+
+	value = fitWithHash(strings.TrimSpace(replaceNotAllowed(value, isTagRune)), MaxTagValueLength, strings.TrimSpace)
+
+	return key, value, nil
+}
+
+// NewTags normalizes a tag set into valid AWS tags, reporting every error at
+// once: keys that collide after normalization and sets over 50 tags.
+func NewTags(tags map[string]string) (map[string]string, error) {
+	return normalizeSet("tag", tags, MaxTags, NewTag)
+}
+
+// isTagRune reports whether r is allowed in AWS tag keys and values.
+func isTagRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == ' ' || strings.ContainsRune("+-=._:/@", r)
+}
@@ -0,0 +1,193 @@
+package namer_test
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+)
+
+func TestNewTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		key           string
+		value         string
+		expectedKey   string
+		expectedValue string
+	}{
+		{
+			name:          "allowed characters unchanged",
+			key:           "Cost Center",
+			value:         "team+infra=platform_1.0:/@example",
+			expectedKey:   "Cost Center",
+			expectedValue: "team+infra=platform_1.0:/@example",
+		},
+		{
+			name:          "characters not allowed replaced",
+			key:           "owner#email",
+			value:         "Platform Team <infra>",
+			expectedKey:   "owner-email",
+			expectedValue: "Platform Team -infra-",
+		},
+		{
+			name:          "unicode letters allowed",
+			key:           "Équipe",
+			value:         "Données",
+			expectedKey:   "Équipe",
+			expectedValue: "Données",
+		},
+		{
+			name:          "empty value allowed",
+			key:           "managed",
+			value:         "",
+			expectedKey:   "managed",
+			expectedValue: "",
+		},
+		{
+			name:          "surrounding whitespace trimmed",
+			key:           "  team ",
+			value:         " platform ",
+			expectedKey:   "team",
+			expectedValue: "platform",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			key, value, err := namer.NewTag(testCase.key, testCase.value)
+			if err != nil {
+				t.Fatalf("NewTag() error = %v", err)
+			}
+
+			if key != testCase.expectedKey || value != testCase.expectedValue {
+				t.Errorf("NewTag() = %q, %q, want %q, %q", key, value, testCase.expectedKey, testCase.expectedValue)
+			}
+		})
+	}
+}
+
+func TestNewTag_TruncatedWithHash(t *testing.T) {
+	t.Parallel()
+
+	key, value, err := namer.NewTag(strings.Repeat("key ", 40), strings.Repeat("é", 300))
+	if err != nil {
+		t.Fatalf("NewTag() error = %v", err)
+	}
+
+	if length := utf8.RuneCountInString(key); length > namer.MaxTagKeyLength {
+		t.Errorf("NewTag() key length = %d, want <= %d", length, namer.MaxTagKeyLength)
+	}
+
+	if length := utf8.RuneCountInString(value); length > namer.MaxTagValueLength {
+		t.Errorf("NewTag() value length = %d, want <= %d", length, namer.MaxTagValueLength)
+	}
+
+	other, _, _ := namer.NewTag(strings.Repeat("key ", 41), "")
+	if key == other {
+		t.Errorf("NewTag() = %q for distinct keys, want distinct keys", key)
+	}
+}
+
+func TestNewTag_InvalidKey(t *testing.T) {
+	t.Parallel()
+
+	for _, key := range []string{"", "   ", "aws:cloudformation:stack-name", "AWS:reserved"} {
+		t.Run(key, func(t *testing.T) {
+			t.Parallel()
+
+			if _, _, err := namer.NewTag(key, "value"); err == nil {
+				t.Errorf("NewTag(%q) expected an error", key)
+			}
+		})
+	}
+}
+
+func TestNewTags(t *testing.T) {
+	t.Parallel()
+
+	tags, err := namer.NewTags(map[string]string{
+		"Team":        "Platform",
+		"cost-center": "cc#1234",
+	})
+	if err != nil {
+		t.Fatalf("NewTags() error = %v", err)
+	}
+
+	expected := map[string]string{"Team": "Platform", "cost-center": "cc-1234"}
+	if !maps.Equal(tags, expected) {
+		t.Errorf("NewTags() = %v, want %v", tags, expected)
+	}
+}
+
+func TestNewTags_CompanionLabels(t *testing.T) {
+	t.Parallel()
+
+	_, labels := namer.New("cloudflare-edge-waf").NewResourceNameWithLabels("l7-ruleset-ddos", "managed", 30)
+
+	tags, err := namer.NewTags(labels)
+	if err != nil {
+		t.Fatalf("NewTags() error = %v", err)
+	}
+
+	if !maps.Equal(tags, labels) {
+		t.Errorf("NewTags() = %v, want companion labels unchanged %v", tags, labels)
+	}
+}
+
+func TestNewTags_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tooMany := make(map[string]string, namer.MaxTags+1)
+	for i := range namer.MaxTags + 1 {
+		tooMany[fmt.Sprintf("tag-%d", i)] = "value"
+	}
+
+	tests := []struct {
+		name           string
+		tags           map[string]string
+		expectedErrors []string
+	}{
+		{
+			name:           "too many tags",
+			tags:           tooMany,
+			expectedErrors: []string{"51 tags exceed the limit of 50"},
+		},
+		{
+			name:           "keys colliding after normalization",
+			tags:           map[string]string{"team#1": "a", "team$1": "b"},
+			expectedErrors: []string{`tag keys "team#1" and "team$1" are both normalized to "team-1"`},
+		},
+		{
+			name: "every invalid key is reported",
+			tags: map[string]string{"aws:name": "a", "": "b", "valid": "c"},
+			expectedErrors: []string{
+				"tag key must not be empty",
+				`tag key "aws:name" must not start with "aws:"`,
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			tags, err := namer.NewTags(testCase.tags)
+			if err == nil {
+				t.Fatalf("NewTags() = %v, want an error", tags)
+			}
+
+			for _, expected := range testCase.expectedErrors {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("NewTags() error = %v, want it to contain %q", err, expected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package namer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// templateKeyPattern matches the key of a template placeholder.
+var templateKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Template is a name layout declared once and rendered by the Namer.
+// E.g.: "{org}-{env}-{name}-{type?}" or "{type}-{name}-{env}" for Azure CAF.
+//
+// Placeholders are set between braces and are required unless they end with
+// "?". Any other text is a literal token that is never truncated. Hyphens,
+// underscores, periods and spaces only delimit elements; the final name joins
+// them with the Namer separator.
+//
+// The keys "base", "name" and "type" are bound to the base name and the
+// NewResourceName arguments. Any other key is bound to a value set with
+// WithValue or to a segment of the same name passed to NewName.
+type Template struct {
+	layout   string
+	elements []templateElement
+}
+
+// templateElement is either a placeholder or a literal token.
+type templateElement struct {
+	key      string
+	literal  string
+	optional bool
+}
+
+// ParseTemplate parses a name layout.
+func ParseTemplate(layout string) (Template, error) {
+	template := Template{layout: layout}
+	keys := make(map[string]bool)
+
+	rest := layout
+	for rest != "" {
+		start := strings.IndexAny(rest, "{}")
+		if start < 0 {
+			template.elements = append(template.elements, literalElements(rest)...)
+
+			break
+		}
+		if rest[start] == '}' {
+			return Template{}, fmt.Errorf("template %q has an unexpected '}'", layout)
+		}
+
+		template.elements = append(template.elements, literalElements(rest[:start])...)
+
+		end := strings.IndexAny(rest[start+1:], "{}")
+		if end < 0 || rest[start+1+end] == '{' {
+			return Template{}, fmt.Errorf("template %q has an unclosed '{'", layout)
+		}
+
+		key := rest[start+1 : start+1+end]
+		optional := strings.HasSuffix(key, "?")
+		key = strings.TrimSuffix(key, "?")
+
+		if !templateKeyPattern.MatchString(key) {
+			return Template{}, fmt.Errorf("template %q has an invalid placeholder %q", layout, key)
+		}
+		if keys[key] {
+			return Template{}, fmt.Errorf("template %q has a duplicate placeholder %q", layout, key)
+		}
+		keys[key] = true
+
+		template.elements = append(template.elements, templateElement{key: key, optional: optional})
+		rest = rest[start+1+end+1:]
+	}
+
+	if len(keys) == 0 {
+		return Template{}, fmt.Errorf("template %q has no placeholders", layout)
+	}
+
+	return template, nil
+}
+
+// MustParseTemplate is like ParseTemplate but panics if the layout is invalid.
+func MustParseTemplate(layout string) Template {
+	template, err := ParseTemplate(layout)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return template
+}
+
+// String returns the layout the template was parsed from.
+func (t Template) String() string {
+	return t.layout
+}
+
+// WithTemplate sets the layout of every name generated by the Namer.
+func WithTemplate(template Template) Option {
+	return func(n *Namer) {
+		n.template = &template
+	}
+}
+
+// WithValue sets the value of a template placeholder for all names. E.g.: org.
+func WithValue(key, value string) Option {
+	return func(n *Namer) {
+		values := make(map[string]string, len(n.values)+1)
+		for k, v := range n.values {
+			values[k] = v
+		}
+		values[key] = value
+		n.values = values
+	}
+}
+
+// templateValues binds the base name, the Namer values and the given segments
+// to template keys. Segments take precedence over Namer values.
+func (e Namer) templateValues(segments []Segment) map[string]Segment {
+	values := make(map[string]Segment, len(e.values)+len(segments)+1)
+	values[SegmentBase] = e.baseSegment()
+	for key, value := range e.values {
+		values[key] = Segment{Name: key, Value: value}
+	}
+	for _, segment := range segments {
+		values[segment.Name] = segment
+	}
+
+	return values
+}
+
+// render lays out the segments in template order. Literal tokens become fixed
+// segments and optional placeholders without a value are left out.
+func (t Template) render(values map[string]Segment) ([]Segment, error) {
+	segments := make([]Segment, 0, len(t.elements))
+
+	for _, element := range t.elements {
+		if element.key == "" {
+			segments = append(segments, Segment{Value: element.literal, Fixed: true})
+
+			continue
+		}
+
+		segment, ok := values[element.key]
+		if !ok || segment.Value == "" {
+			if element.optional {
+				continue
+			}
+
+			return nil, fmt.Errorf("template %q requires a value for %q", t.layout, element.key)
+		}
+		segment.Name = element.key
+		segments = append(segments, segment)
+	}
+
+	return segments, nil
+}
+
+// checkSegments ensures every non-empty segment has a placeholder, so values
+// aren't silently left out of the name.
+func (t Template) checkSegments(segments []Segment) error {
+	for _, segment := range segments {
+		if segment.Value != "" && !t.hasKey(segment.Name) {
+			return fmt.Errorf("template %q has no placeholder for %q", t.layout, segment.Name)
+		}
+	}
+
+	return nil
+}
+
+// hasKey reports whether the template has a placeholder for the key.
+func (t Template) hasKey(key string) bool {
+	for _, element := range t.elements {
+		if element.key == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// literalElements splits the text between placeholders into literal tokens.
+func literalElements(text string) []templateElement {
+	tokens := strings.FieldsFunc(text, isTemplateDelimiter)
+	elements := make([]templateElement, len(tokens))
+	for i, token := range tokens {
+		elements[i] = templateElement{literal: token}
+	}
+
+	return elements
+}
+
+// isTemplateDelimiter reports whether r delimits template elements.
+func isTemplateDelimiter(r rune) bool {
+	return r == '-' || r == '_' || r == '.' || r == ' '
+}
@@ -0,0 +1,188 @@
+package namer_test
+
+import (
+	"testing"
+
+	namer "github.com/davidmontoyago/commodity-namer"
+)
+
+func TestNewResourceName_WithTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		baseName     string
+		template     string
+		values       map[string]string
+		serviceName  string
+		resourceType string
+		maxLength    int
+		expected     string
+	}{
+		{
+			name:         "org and env before name and type",
+			baseName:     "stack",
+			template:     "{org}-{env}-{name}-{type}",
+			values:       map[string]string{"org": "acme", "env": "prd"},
+			serviceName:  "orders",
+			resourceType: "bucket",
+			maxLength:    63,
+			expected:     "acme-prd-orders-bucket",
+		},
+		{
+			name:         "type first for azure caf",
+			baseName:     "stack",
+			template:     "{type}-{name}-{env}",
+			values:       map[string]string{"env": "prd"},
+			serviceName:  "orders",
+			resourceType: "st",
+			maxLength:    24,
+			expected:     "st-orders-prd",
+		},
+		{
+			name:         "optional placeholders without value are left out",
+			baseName:     "",
+			template:     "{base?}-{name}-{type?}",
+			serviceName:  "orders",
+			resourceType: "",
+			maxLength:    63,
+			expected:     "orders",
+		},
+		{
+			name:         "any delimiter between elements",
+			baseName:     "stack",
+			template:     "{org}_{env}.{base?} {name}-{type?}",
+			values:       map[string]string{"org": "acme", "env": "prd"},
+			serviceName:  "orders",
+			resourceType: "",
+			maxLength:    63,
+			expected:     "acme-prd-stack-orders",
+		},
+		{
+			name:         "literal tokens are never truncated",
+			baseName:     "my-prod-stack",
+			template:     "{base}-k8s-{name}-{type}",
+			serviceName:  "backend-processor",
+			resourceType: "service-account",
+			maxLength:    30,
+			expected:     "my-prod-k8s-backend-p-service",
+		},
+		{
+			name:         "base name is still truncated first",
+			baseName:     "cloudflare-edge-waf",
+			template:     "{name}-{type}-{base}",
+			serviceName:  "l7-ruleset-ddos",
+			resourceType: "managed",
+			maxLength:    30,
+			expected:     "l7-ruleset-ddos-managed-cloudf",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := []namer.Option{namer.WithTemplate(namer.MustParseTemplate(testCase.template))}
+			for key, value := range testCase.values {
+				opts = append(opts, namer.WithValue(key, value))
+			}
+
+			n := namer.New(testCase.baseName, opts...)
+			result := n.NewResourceName(testCase.serviceName, testCase.resourceType, testCase.maxLength)
+
+			if result != testCase.expected {
+				t.Errorf("NewResourceName() = %v, want %v", result, testCase.expected)
+			}
+
+			if len(result) > testCase.maxLength {
+				t.Errorf("NewResourceName() length = %d, want <= %d", len(result), testCase.maxLength)
+			}
+		})
+	}
+}
+
+func TestNewName_WithTemplate(t *testing.T) {
+	t.Parallel()
+
+	n := namer.New("stack",
+		namer.WithTemplate(namer.MustParseTemplate("{org}-{env}-{service}-{component?}-{type}")),
+		namer.WithValue("org", "acme"),
+		namer.WithValue("env", "stg"),
+	)
+
+	result := n.NewName(63,
+		namer.Segment{Name: "type", Value: "bucket"},
+		namer.Segment{Name: "service", Value: "orders"},
+		namer.Segment{Name: "env", Value: "prd"},
+	)
+
+	expected := "acme-prd-orders-bucket"
+	if result != expected {
+		t.Errorf("NewName() = %v, want %v", result, expected)
+	}
+}
+
+func TestParseTemplate_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		layout string
+	}{
+		{name: "unclosed placeholder", layout: "{org}-{name"},
+		{name: "unexpected closing brace", layout: "org}-{name}"},
+		{name: "nested placeholder", layout: "{org-{name}}"},
+		{name: "empty placeholder", layout: "{org}-{}"},
+		{name: "uppercase placeholder", layout: "{Org}-{name}"},
+		{name: "duplicate placeholder", layout: "{name}-{name}"},
+		{name: "no placeholders", layout: "just-literals"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := namer.ParseTemplate(testCase.layout); err == nil {
+				t.Errorf("ParseTemplate(%q) expected an error", testCase.layout)
+			}
+		})
+	}
+}
+
+func TestNewName_WithTemplateInvalidValues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		segments []namer.Segment
+	}{
+		{
+			name:     "missing required value",
+			segments: []namer.Segment{{Name: "type", Value: "bucket"}},
+		},
+		{
+			name: "segment without placeholder",
+			segments: []namer.Segment{
+				{Name: "name", Value: "orders"},
+				{Name: "region", Value: "usc1"},
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected panic but none occurred")
+				}
+			}()
+
+			n := namer.New("stack", namer.WithTemplate(namer.MustParseTemplate("{base}-{name}-{type?}")))
+			result := n.NewName(63, testCase.segments...)
+
+			t.Errorf("Expected panic but got result: %s", result)
+		})
+	}
+}